@@ -9,6 +9,7 @@ package weatherv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,6 +21,115 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Units selects the unit system of the Quantity fields; unspecified is US.
+type Units int32
+
+const (
+	Units_UNITS_UNSPECIFIED Units = 0
+	Units_UNITS_US          Units = 1 // F, mph, mi, mb
+	Units_UNITS_SI          Units = 2 // C, m/s, km, hPa
+	Units_UNITS_METRIC      Units = 3 // C, km/h, km, hPa
+)
+
+// Enum value maps for Units.
+var (
+	Units_name = map[int32]string{
+		0: "UNITS_UNSPECIFIED",
+		1: "UNITS_US",
+		2: "UNITS_SI",
+		3: "UNITS_METRIC",
+	}
+	Units_value = map[string]int32{
+		"UNITS_UNSPECIFIED": 0,
+		"UNITS_US":          1,
+		"UNITS_SI":          2,
+		"UNITS_METRIC":      3,
+	}
+)
+
+func (x Units) Enum() *Units {
+	p := new(Units)
+	*p = x
+	return p
+}
+
+func (x Units) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Units) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_weather_proto_enumTypes[0].Descriptor()
+}
+
+func (Units) Type() protoreflect.EnumType {
+	return &file_api_proto_weather_proto_enumTypes[0]
+}
+
+func (x Units) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Units.Descriptor instead.
+func (Units) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{0}
+}
+
+// Quantity is a measurement with the symbol of its unit, e.g. "C" or "km/h".
+type Quantity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Unit  string  `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+}
+
+func (x *Quantity) Reset() {
+	*x = Quantity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quantity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quantity) ProtoMessage() {}
+
+func (x *Quantity) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quantity.ProtoReflect.Descriptor instead.
+func (*Quantity) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Quantity) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Quantity) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
 type LatLonRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -27,12 +137,18 @@ type LatLonRequest struct {
 
 	Lat float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
 	Lon float64 `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	// category profile, the default one when empty
+	Profile string `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	Units   Units  `protobuf:"varint,4,opt,name=units,proto3,enum=weather.v1.Units" json:"units,omitempty"`
+	// language of texts and error messages, e.g. "es" or "es-MX"; unknown
+	// locales fall back to English
+	Locale string `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
 }
 
 func (x *LatLonRequest) Reset() {
 	*x = LatLonRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_weather_proto_msgTypes[0]
+		mi := &file_api_proto_weather_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -45,7 +161,7 @@ func (x *LatLonRequest) String() string {
 func (*LatLonRequest) ProtoMessage() {}
 
 func (x *LatLonRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_weather_proto_msgTypes[0]
+	mi := &file_api_proto_weather_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,7 +174,7 @@ func (x *LatLonRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LatLonRequest.ProtoReflect.Descriptor instead.
 func (*LatLonRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_weather_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *LatLonRequest) GetLat() float64 {
@@ -75,6 +191,27 @@ func (x *LatLonRequest) GetLon() float64 {
 	return 0
 }
 
+func (x *LatLonRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *LatLonRequest) GetUnits() Units {
+	if x != nil {
+		return x.Units
+	}
+	return Units_UNITS_UNSPECIFIED
+}
+
+func (x *LatLonRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
 type ForecastReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -83,12 +220,27 @@ type ForecastReply struct {
 	ShortForecast string  `protobuf:"bytes,1,opt,name=short_forecast,json=shortForecast,proto3" json:"short_forecast,omitempty"`
 	TemperatureF  float64 `protobuf:"fixed64,2,opt,name=temperature_f,json=temperatureF,proto3" json:"temperature_f,omitempty"`
 	Category      string  `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Stale         bool    `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"`
+	// today's daytime high and tonight's low in the location's time zone
+	HighF *float64 `protobuf:"fixed64,5,opt,name=high_f,json=highF,proto3,oneof" json:"high_f,omitempty"`
+	LowF  *float64 `protobuf:"fixed64,6,opt,name=low_f,json=lowF,proto3,oneof" json:"low_f,omitempty"`
+	// heat index or wind chill, temperature_f when neither applies
+	FeelsLikeF float64 `protobuf:"fixed64,7,opt,name=feels_like_f,json=feelsLikeF,proto3" json:"feels_like_f,omitempty"`
+	// the fields above in the requested units; *_f fields are always °F
+	Temperature *Quantity `protobuf:"bytes,8,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	FeelsLike   *Quantity `protobuf:"bytes,9,opt,name=feels_like,json=feelsLike,proto3" json:"feels_like,omitempty"`
+	High        *Quantity `protobuf:"bytes,10,opt,name=high,proto3" json:"high,omitempty"`
+	Low         *Quantity `protobuf:"bytes,11,opt,name=low,proto3" json:"low,omitempty"`
+	// category translated to the requested locale
+	CategoryText string `protobuf:"bytes,12,opt,name=category_text,json=categoryText,proto3" json:"category_text,omitempty"`
+	// one-line description of the fields above in the requested locale
+	Summary string `protobuf:"bytes,13,opt,name=summary,proto3" json:"summary,omitempty"`
 }
 
 func (x *ForecastReply) Reset() {
 	*x = ForecastReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_weather_proto_msgTypes[1]
+		mi := &file_api_proto_weather_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -101,7 +253,7 @@ func (x *ForecastReply) String() string {
 func (*ForecastReply) ProtoMessage() {}
 
 func (x *ForecastReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_weather_proto_msgTypes[1]
+	mi := &file_api_proto_weather_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,7 +266,7 @@ func (x *ForecastReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ForecastReply.ProtoReflect.Descriptor instead.
 func (*ForecastReply) Descriptor() ([]byte, []int) {
-	return file_api_proto_weather_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ForecastReply) GetShortForecast() string {
@@ -138,81 +290,1517 @@ func (x *ForecastReply) GetCategory() string {
 	return ""
 }
 
-var File_api_proto_weather_proto protoreflect.FileDescriptor
+func (x *ForecastReply) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
 
-var file_api_proto_weather_proto_rawDesc = []byte{
-	0x0a, 0x17, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x77, 0x65, 0x61, 0x74,
-	0x68, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x77, 0x65, 0x61, 0x74, 0x68,
-	0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x33, 0x0a, 0x0d, 0x4c, 0x61, 0x74, 0x4c, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x6e, 0x22, 0x77, 0x0a, 0x0d, 0x46, 0x6f,
-	0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x73,
-	0x68, 0x6f, 0x72, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61,
-	0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x5f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67,
-	0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67,
-	0x6f, 0x72, 0x79, 0x32, 0x5a, 0x0a, 0x0e, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x64, 0x61,
-	0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x77, 0x65, 0x61, 0x74,
-	0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6f, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42,
-	0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x63,
-	0x67, 0x6c, 0x65, 0x7a, 0x72, 0x65, 0x79, 0x65, 0x73, 0x2f, 0x67, 0x6f, 0x5f, 0x77, 0x65, 0x61,
-	0x74, 0x68, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x77,
-	0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *ForecastReply) GetHighF() float64 {
+	if x != nil && x.HighF != nil {
+		return *x.HighF
+	}
+	return 0
 }
 
-var (
-	file_api_proto_weather_proto_rawDescOnce sync.Once
-	file_api_proto_weather_proto_rawDescData = file_api_proto_weather_proto_rawDesc
-)
+func (x *ForecastReply) GetLowF() float64 {
+	if x != nil && x.LowF != nil {
+		return *x.LowF
+	}
+	return 0
+}
 
-func file_api_proto_weather_proto_rawDescGZIP() []byte {
-	file_api_proto_weather_proto_rawDescOnce.Do(func() {
-		file_api_proto_weather_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proto_weather_proto_rawDescData)
-	})
-	return file_api_proto_weather_proto_rawDescData
+func (x *ForecastReply) GetFeelsLikeF() float64 {
+	if x != nil {
+		return x.FeelsLikeF
+	}
+	return 0
 }
 
-var file_api_proto_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_api_proto_weather_proto_goTypes = []interface{}{
-	(*LatLonRequest)(nil), // 0: weather.v1.LatLonRequest
-	(*ForecastReply)(nil), // 1: weather.v1.ForecastReply
+func (x *ForecastReply) GetTemperature() *Quantity {
+	if x != nil {
+		return x.Temperature
+	}
+	return nil
 }
-var file_api_proto_weather_proto_depIdxs = []int32{
-	0, // 0: weather.v1.WeatherService.GetTodayForecast:input_type -> weather.v1.LatLonRequest
-	1, // 1: weather.v1.WeatherService.GetTodayForecast:output_type -> weather.v1.ForecastReply
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *ForecastReply) GetFeelsLike() *Quantity {
+	if x != nil {
+		return x.FeelsLike
+	}
+	return nil
 }
 
-func init() { file_api_proto_weather_proto_init() }
-func file_api_proto_weather_proto_init() {
-	if File_api_proto_weather_proto != nil {
-		return
+func (x *ForecastReply) GetHigh() *Quantity {
+	if x != nil {
+		return x.High
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_api_proto_weather_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LatLonRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+func (x *ForecastReply) GetLow() *Quantity {
+	if x != nil {
+		return x.Low
+	}
+	return nil
+}
+
+func (x *ForecastReply) GetCategoryText() string {
+	if x != nil {
+		return x.CategoryText
+	}
+	return ""
+}
+
+func (x *ForecastReply) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+// days is 1-7, all of them when unset
+type DailyForecastRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lat    float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon    float64 `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	Days   int32   `protobuf:"varint,3,opt,name=days,proto3" json:"days,omitempty"`
+	Units  Units   `protobuf:"varint,4,opt,name=units,proto3,enum=weather.v1.Units" json:"units,omitempty"`
+	Locale string  `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+}
+
+func (x *DailyForecastRequest) Reset() {
+	*x = DailyForecastRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DailyForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyForecastRequest) ProtoMessage() {}
+
+func (x *DailyForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_api_proto_weather_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ForecastReply); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyForecastRequest.ProtoReflect.Descriptor instead.
+func (*DailyForecastRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DailyForecastRequest) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *DailyForecastRequest) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *DailyForecastRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *DailyForecastRequest) GetUnits() Units {
+	if x != nil {
+		return x.Units
+	}
+	return Units_UNITS_UNSPECIFIED
+}
+
+func (x *DailyForecastRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type ForecastPeriod struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	StartTime        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	IsDaytime        bool                   `protobuf:"varint,4,opt,name=is_daytime,json=isDaytime,proto3" json:"is_daytime,omitempty"`
+	TemperatureF     float64                `protobuf:"fixed64,5,opt,name=temperature_f,json=temperatureF,proto3" json:"temperature_f,omitempty"`
+	ShortForecast    string                 `protobuf:"bytes,6,opt,name=short_forecast,json=shortForecast,proto3" json:"short_forecast,omitempty"`
+	DetailedForecast string                 `protobuf:"bytes,7,opt,name=detailed_forecast,json=detailedForecast,proto3" json:"detailed_forecast,omitempty"`
+	Temperature      *Quantity              `protobuf:"bytes,8,opt,name=temperature,proto3" json:"temperature,omitempty"`
+}
+
+func (x *ForecastPeriod) Reset() {
+	*x = ForecastPeriod{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForecastPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastPeriod) ProtoMessage() {}
+
+func (x *ForecastPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastPeriod.ProtoReflect.Descriptor instead.
+func (*ForecastPeriod) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ForecastPeriod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ForecastPeriod) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ForecastPeriod) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ForecastPeriod) GetIsDaytime() bool {
+	if x != nil {
+		return x.IsDaytime
+	}
+	return false
+}
+
+func (x *ForecastPeriod) GetTemperatureF() float64 {
+	if x != nil {
+		return x.TemperatureF
+	}
+	return 0
+}
+
+func (x *ForecastPeriod) GetShortForecast() string {
+	if x != nil {
+		return x.ShortForecast
+	}
+	return ""
+}
+
+func (x *ForecastPeriod) GetDetailedForecast() string {
+	if x != nil {
+		return x.DetailedForecast
+	}
+	return ""
+}
+
+func (x *ForecastPeriod) GetTemperature() *Quantity {
+	if x != nil {
+		return x.Temperature
+	}
+	return nil
+}
+
+type DailyForecastReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Periods []*ForecastPeriod `protobuf:"bytes,1,rep,name=periods,proto3" json:"periods,omitempty"`
+}
+
+func (x *DailyForecastReply) Reset() {
+	*x = DailyForecastReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DailyForecastReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyForecastReply) ProtoMessage() {}
+
+func (x *DailyForecastReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyForecastReply.ProtoReflect.Descriptor instead.
+func (*DailyForecastReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DailyForecastReply) GetPeriods() []*ForecastPeriod {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+// hours is 1-156, 24 when unset
+type HourlyForecastRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lat    float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon    float64 `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	Hours  int32   `protobuf:"varint,3,opt,name=hours,proto3" json:"hours,omitempty"`
+	Units  Units   `protobuf:"varint,4,opt,name=units,proto3,enum=weather.v1.Units" json:"units,omitempty"`
+	Locale string  `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+}
+
+func (x *HourlyForecastRequest) Reset() {
+	*x = HourlyForecastRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HourlyForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HourlyForecastRequest) ProtoMessage() {}
+
+func (x *HourlyForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HourlyForecastRequest.ProtoReflect.Descriptor instead.
+func (*HourlyForecastRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HourlyForecastRequest) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *HourlyForecastRequest) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *HourlyForecastRequest) GetHours() int32 {
+	if x != nil {
+		return x.Hours
+	}
+	return 0
+}
+
+func (x *HourlyForecastRequest) GetUnits() Units {
+	if x != nil {
+		return x.Units
+	}
+	return Units_UNITS_UNSPECIFIED
+}
+
+func (x *HourlyForecastRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type HourlyPeriod struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartTime                *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime                  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	TemperatureF             float64                `protobuf:"fixed64,3,opt,name=temperature_f,json=temperatureF,proto3" json:"temperature_f,omitempty"`
+	WindSpeedMph             float64                `protobuf:"fixed64,4,opt,name=wind_speed_mph,json=windSpeedMph,proto3" json:"wind_speed_mph,omitempty"`
+	WindDirection            string                 `protobuf:"bytes,5,opt,name=wind_direction,json=windDirection,proto3" json:"wind_direction,omitempty"`
+	PrecipitationProbability float64                `protobuf:"fixed64,6,opt,name=precipitation_probability,json=precipitationProbability,proto3" json:"precipitation_probability,omitempty"`
+	ShortForecast            string                 `protobuf:"bytes,7,opt,name=short_forecast,json=shortForecast,proto3" json:"short_forecast,omitempty"`
+	Temperature              *Quantity              `protobuf:"bytes,8,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	WindSpeed                *Quantity              `protobuf:"bytes,9,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`
+}
+
+func (x *HourlyPeriod) Reset() {
+	*x = HourlyPeriod{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HourlyPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HourlyPeriod) ProtoMessage() {}
+
+func (x *HourlyPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HourlyPeriod.ProtoReflect.Descriptor instead.
+func (*HourlyPeriod) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HourlyPeriod) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *HourlyPeriod) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *HourlyPeriod) GetTemperatureF() float64 {
+	if x != nil {
+		return x.TemperatureF
+	}
+	return 0
+}
+
+func (x *HourlyPeriod) GetWindSpeedMph() float64 {
+	if x != nil {
+		return x.WindSpeedMph
+	}
+	return 0
+}
+
+func (x *HourlyPeriod) GetWindDirection() string {
+	if x != nil {
+		return x.WindDirection
+	}
+	return ""
+}
+
+func (x *HourlyPeriod) GetPrecipitationProbability() float64 {
+	if x != nil {
+		return x.PrecipitationProbability
+	}
+	return 0
+}
+
+func (x *HourlyPeriod) GetShortForecast() string {
+	if x != nil {
+		return x.ShortForecast
+	}
+	return ""
+}
+
+func (x *HourlyPeriod) GetTemperature() *Quantity {
+	if x != nil {
+		return x.Temperature
+	}
+	return nil
+}
+
+func (x *HourlyPeriod) GetWindSpeed() *Quantity {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return nil
+}
+
+type HourlyForecastReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hours []*HourlyPeriod `protobuf:"bytes,1,rep,name=hours,proto3" json:"hours,omitempty"`
+}
+
+func (x *HourlyForecastReply) Reset() {
+	*x = HourlyForecastReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HourlyForecastReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HourlyForecastReply) ProtoMessage() {}
+
+func (x *HourlyForecastReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HourlyForecastReply.ProtoReflect.Descriptor instead.
+func (*HourlyForecastReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HourlyForecastReply) GetHours() []*HourlyPeriod {
+	if x != nil {
+		return x.Hours
+	}
+	return nil
+}
+
+type AlertsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lat    float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon    float64 `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	Zone   string  `protobuf:"bytes,3,opt,name=zone,proto3" json:"zone,omitempty"`
+	Locale string  `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+}
+
+func (x *AlertsRequest) Reset() {
+	*x = AlertsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertsRequest) ProtoMessage() {}
+
+func (x *AlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertsRequest.ProtoReflect.Descriptor instead.
+func (*AlertsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AlertsRequest) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *AlertsRequest) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *AlertsRequest) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *AlertsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type Alert struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Event         string                 `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	Severity      string                 `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	Urgency       string                 `protobuf:"bytes,4,opt,name=urgency,proto3" json:"urgency,omitempty"`
+	Certainty     string                 `protobuf:"bytes,5,opt,name=certainty,proto3" json:"certainty,omitempty"`
+	Onset         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=onset,proto3" json:"onset,omitempty"`
+	Expires       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires,proto3" json:"expires,omitempty"`
+	Headline      string                 `protobuf:"bytes,8,opt,name=headline,proto3" json:"headline,omitempty"`
+	Description   string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	Instruction   string                 `protobuf:"bytes,10,opt,name=instruction,proto3" json:"instruction,omitempty"`
+	AreaDesc      string                 `protobuf:"bytes,11,opt,name=area_desc,json=areaDesc,proto3" json:"area_desc,omitempty"`
+	AffectedZones []string               `protobuf:"bytes,12,rep,name=affected_zones,json=affectedZones,proto3" json:"affected_zones,omitempty"`
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Alert) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Alert) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *Alert) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Alert) GetUrgency() string {
+	if x != nil {
+		return x.Urgency
+	}
+	return ""
+}
+
+func (x *Alert) GetCertainty() string {
+	if x != nil {
+		return x.Certainty
+	}
+	return ""
+}
+
+func (x *Alert) GetOnset() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Onset
+	}
+	return nil
+}
+
+func (x *Alert) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+func (x *Alert) GetHeadline() string {
+	if x != nil {
+		return x.Headline
+	}
+	return ""
+}
+
+func (x *Alert) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Alert) GetInstruction() string {
+	if x != nil {
+		return x.Instruction
+	}
+	return ""
+}
+
+func (x *Alert) GetAreaDesc() string {
+	if x != nil {
+		return x.AreaDesc
+	}
+	return ""
+}
+
+func (x *Alert) GetAffectedZones() []string {
+	if x != nil {
+		return x.AffectedZones
+	}
+	return nil
+}
+
+type AlertsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Alerts []*Alert `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+}
+
+func (x *AlertsReply) Reset() {
+	*x = AlertsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlertsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertsReply) ProtoMessage() {}
+
+func (x *AlertsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertsReply.ProtoReflect.Descriptor instead.
+func (*AlertsReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AlertsReply) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+type ConditionsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StationId         string                 `protobuf:"bytes,1,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	StationName       string                 `protobuf:"bytes,2,opt,name=station_name,json=stationName,proto3" json:"station_name,omitempty"`
+	StationDistanceKm float64                `protobuf:"fixed64,3,opt,name=station_distance_km,json=stationDistanceKm,proto3" json:"station_distance_km,omitempty"`
+	ObservedAt        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
+	TextDescription   string                 `protobuf:"bytes,5,opt,name=text_description,json=textDescription,proto3" json:"text_description,omitempty"`
+	TemperatureF      *float64               `protobuf:"fixed64,6,opt,name=temperature_f,json=temperatureF,proto3,oneof" json:"temperature_f,omitempty"`
+	DewpointF         *float64               `protobuf:"fixed64,7,opt,name=dewpoint_f,json=dewpointF,proto3,oneof" json:"dewpoint_f,omitempty"`
+	RelativeHumidity  *float64               `protobuf:"fixed64,8,opt,name=relative_humidity,json=relativeHumidity,proto3,oneof" json:"relative_humidity,omitempty"`
+	WindSpeedMph      *float64               `protobuf:"fixed64,9,opt,name=wind_speed_mph,json=windSpeedMph,proto3,oneof" json:"wind_speed_mph,omitempty"`
+	WindDirectionDeg  *float64               `protobuf:"fixed64,10,opt,name=wind_direction_deg,json=windDirectionDeg,proto3,oneof" json:"wind_direction_deg,omitempty"`
+	PressureMb        *float64               `protobuf:"fixed64,11,opt,name=pressure_mb,json=pressureMb,proto3,oneof" json:"pressure_mb,omitempty"`
+	VisibilityMiles   *float64               `protobuf:"fixed64,12,opt,name=visibility_miles,json=visibilityMiles,proto3,oneof" json:"visibility_miles,omitempty"`
+	Category          string                 `protobuf:"bytes,13,opt,name=category,proto3" json:"category,omitempty"`
+	FeelsLikeF        *float64               `protobuf:"fixed64,14,opt,name=feels_like_f,json=feelsLikeF,proto3,oneof" json:"feels_like_f,omitempty"`
+	// the measurements above in the requested units
+	Temperature     *Quantity `protobuf:"bytes,15,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	FeelsLike       *Quantity `protobuf:"bytes,16,opt,name=feels_like,json=feelsLike,proto3" json:"feels_like,omitempty"`
+	Dewpoint        *Quantity `protobuf:"bytes,17,opt,name=dewpoint,proto3" json:"dewpoint,omitempty"`
+	WindSpeed       *Quantity `protobuf:"bytes,18,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`
+	Pressure        *Quantity `protobuf:"bytes,19,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	Visibility      *Quantity `protobuf:"bytes,20,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	StationDistance *Quantity `protobuf:"bytes,21,opt,name=station_distance,json=stationDistance,proto3" json:"station_distance,omitempty"`
+	CategoryText    string    `protobuf:"bytes,22,opt,name=category_text,json=categoryText,proto3" json:"category_text,omitempty"`
+}
+
+func (x *ConditionsReply) Reset() {
+	*x = ConditionsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_weather_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConditionsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionsReply) ProtoMessage() {}
+
+func (x *ConditionsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_weather_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionsReply.ProtoReflect.Descriptor instead.
+func (*ConditionsReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_weather_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ConditionsReply) GetStationId() string {
+	if x != nil {
+		return x.StationId
+	}
+	return ""
+}
+
+func (x *ConditionsReply) GetStationName() string {
+	if x != nil {
+		return x.StationName
+	}
+	return ""
+}
+
+func (x *ConditionsReply) GetStationDistanceKm() float64 {
+	if x != nil {
+		return x.StationDistanceKm
+	}
+	return 0
+}
+
+func (x *ConditionsReply) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+func (x *ConditionsReply) GetTextDescription() string {
+	if x != nil {
+		return x.TextDescription
+	}
+	return ""
+}
+
+func (x *ConditionsReply) GetTemperatureF() float64 {
+	if x != nil && x.TemperatureF != nil {
+		return *x.TemperatureF
+	}
+	return 0
+}
+
+func (x *ConditionsReply) GetDewpointF() float64 {
+	if x != nil && x.DewpointF != nil {
+		return *x.DewpointF
+	}
+	return 0
+}
+
+func (x *ConditionsReply) GetRelativeHumidity() float64 {
+	if x != nil && x.RelativeHumidity != nil {
+		return *x.RelativeHumidity
+	}
+	return 0
+}
+
+func (x *ConditionsReply) GetWindSpeedMph() float64 {
+	if x != nil && x.WindSpeedMph != nil {
+		return *x.WindSpeedMph
+	}
+	return 0
+}
+
+func (x *ConditionsReply) GetWindDirectionDeg() float64 {
+	if x != nil && x.WindDirectionDeg != nil {
+		return *x.WindDirectionDeg
+	}
+	return 0
+}
+
+func (x *ConditionsReply) GetPressureMb() float64 {
+	if x != nil && x.PressureMb != nil {
+		return *x.PressureMb
+	}
+	return 0
+}
+
+func (x *ConditionsReply) GetVisibilityMiles() float64 {
+	if x != nil && x.VisibilityMiles != nil {
+		return *x.VisibilityMiles
+	}
+	return 0
+}
+
+func (x *ConditionsReply) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ConditionsReply) GetFeelsLikeF() float64 {
+	if x != nil && x.FeelsLikeF != nil {
+		return *x.FeelsLikeF
+	}
+	return 0
+}
+
+func (x *ConditionsReply) GetTemperature() *Quantity {
+	if x != nil {
+		return x.Temperature
+	}
+	return nil
+}
+
+func (x *ConditionsReply) GetFeelsLike() *Quantity {
+	if x != nil {
+		return x.FeelsLike
+	}
+	return nil
+}
+
+func (x *ConditionsReply) GetDewpoint() *Quantity {
+	if x != nil {
+		return x.Dewpoint
+	}
+	return nil
+}
+
+func (x *ConditionsReply) GetWindSpeed() *Quantity {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return nil
+}
+
+func (x *ConditionsReply) GetPressure() *Quantity {
+	if x != nil {
+		return x.Pressure
+	}
+	return nil
+}
+
+func (x *ConditionsReply) GetVisibility() *Quantity {
+	if x != nil {
+		return x.Visibility
+	}
+	return nil
+}
+
+func (x *ConditionsReply) GetStationDistance() *Quantity {
+	if x != nil {
+		return x.StationDistance
+	}
+	return nil
+}
+
+func (x *ConditionsReply) GetCategoryText() string {
+	if x != nil {
+		return x.CategoryText
+	}
+	return ""
+}
+
+var File_api_proto_weather_proto protoreflect.FileDescriptor
+
+var file_api_proto_weather_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x77, 0x65, 0x61, 0x74,
+	0x68, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x77, 0x65, 0x61, 0x74, 0x68,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x34, 0x0a, 0x08, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x22, 0x8e, 0x01, 0x0a,
+	0x0d, 0x4c, 0x61, 0x74, 0x4c, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x6c, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c,
+	0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x27, 0x0a, 0x05,
+	0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x77, 0x65,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x05,
+	0x75, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x22, 0xf8, 0x03,
+	0x0a, 0x0d, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x25, 0x0a, 0x0e, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x46, 0x6f,
+	0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x74,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x1a, 0x0a,
+	0x06, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52,
+	0x05, 0x68, 0x69, 0x67, 0x68, 0x46, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x05, 0x6c, 0x6f, 0x77,
+	0x5f, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x04, 0x6c, 0x6f, 0x77, 0x46,
+	0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x0c, 0x66, 0x65, 0x65, 0x6c, 0x73, 0x5f, 0x6c, 0x69, 0x6b,
+	0x65, 0x5f, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x66, 0x65, 0x65, 0x6c, 0x73,
+	0x4c, 0x69, 0x6b, 0x65, 0x46, 0x12, 0x36, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x65, 0x61,
+	0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x33, 0x0a,
+	0x0a, 0x66, 0x65, 0x65, 0x6c, 0x73, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x09, 0x66, 0x65, 0x65, 0x6c, 0x73, 0x4c, 0x69,
+	0x6b, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x26, 0x0a, 0x03,
+	0x6c, 0x6f, 0x77, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74,
+	0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52,
+	0x03, 0x6c, 0x6f, 0x77, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x54, 0x65, 0x78, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x66, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x6c, 0x6f, 0x77, 0x5f, 0x66, 0x22, 0x8f, 0x01, 0x0a, 0x14, 0x44, 0x61, 0x69,
+	0x6c, 0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03,
+	0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x03, 0x6c, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x75, 0x6e, 0x69,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x05, 0x75, 0x6e, 0x69,
+	0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x22, 0xe6, 0x02, 0x0a, 0x0e, 0x46,
+	0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08,
+	0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x64, 0x61, 0x79, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x44, 0x61, 0x79, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x5f, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x68, 0x6f, 0x72, 0x74,
+	0x5f, 0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x2b,
+	0x0a, 0x11, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x66, 0x6f, 0x72, 0x65, 0x63,
+	0x61, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x0b, 0x74,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x22, 0x4a, 0x0a, 0x12, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x46, 0x6f, 0x72, 0x65,
+	0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x07, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x65, 0x61,
+	0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x07, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x73, 0x22,
+	0x92, 0x01, 0x0a, 0x15, 0x48, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x68, 0x6f,
+	0x75, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x65, 0x22, 0xc3, 0x03, 0x0a, 0x0c, 0x48, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x50,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
+	0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x12, 0x24, 0x0a, 0x0e,
+	0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x6d, 0x70, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x4d,
+	0x70, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x77, 0x69, 0x6e, 0x64,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x19, 0x70, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x70, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x62, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f,
+	0x66, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x73, 0x68, 0x6f, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x36, 0x0a,
+	0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70,
+	0x65, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74,
+	0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52,
+	0x09, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x22, 0x45, 0x0a, 0x13, 0x48, 0x6f,
+	0x75, 0x72, 0x6c, 0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x2e, 0x0a, 0x05, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f,
+	0x75, 0x72, 0x6c, 0x79, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x05, 0x68, 0x6f, 0x75, 0x72,
+	0x73, 0x22, 0x5f, 0x0a, 0x0d, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x03, 0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x03, 0x6c, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x65, 0x22, 0x8d, 0x03, 0x0a, 0x05, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18,
+	0x0a, 0x07, 0x75, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x75, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x65, 0x72, 0x74,
+	0x61, 0x69, 0x6e, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x65, 0x72,
+	0x74, 0x61, 0x69, 0x6e, 0x74, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x6f, 0x6e, 0x73, 0x65, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x05, 0x6f, 0x6e, 0x73, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b,
+	0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b,
+	0x0a, 0x09, 0x61, 0x72, 0x65, 0x61, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x61, 0x72, 0x65, 0x61, 0x44, 0x65, 0x73, 0x63, 0x12, 0x25, 0x0a, 0x0e, 0x61,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x0c, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x66, 0x66, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5a, 0x6f, 0x6e,
+	0x65, 0x73, 0x22, 0x38, 0x0a, 0x0b, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x29, 0x0a, 0x06, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x6c, 0x65, 0x72, 0x74, 0x52, 0x06, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x22, 0x9b, 0x09, 0x0a,
+	0x0f, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x69,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6b, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x11, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x4b, 0x6d, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x29, 0x0a, 0x10, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x65, 0x78, 0x74, 0x44,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0d, 0x74, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x01, 0x48, 0x00, 0x52, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x46, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x64, 0x65, 0x77, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x5f, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x09, 0x64, 0x65, 0x77, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x46, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x11, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x02, 0x52, 0x10, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48,
+	0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x29, 0x0a, 0x0e, 0x77, 0x69,
+	0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x6d, 0x70, 0x68, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x03, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x53, 0x70, 0x65, 0x65, 0x64, 0x4d,
+	0x70, 0x68, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x12, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x01, 0x48, 0x04, 0x52, 0x10, 0x77, 0x69, 0x6e, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x67, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x62, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x48, 0x05, 0x52,
+	0x0a, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x4d, 0x62, 0x88, 0x01, 0x01, 0x12, 0x2e,
+	0x0a, 0x10, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x6d, 0x69, 0x6c,
+	0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01, 0x48, 0x06, 0x52, 0x0f, 0x76, 0x69, 0x73, 0x69,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0c, 0x66, 0x65,
+	0x65, 0x6c, 0x73, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x5f, 0x66, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01,
+	0x48, 0x07, 0x52, 0x0a, 0x66, 0x65, 0x65, 0x6c, 0x73, 0x4c, 0x69, 0x6b, 0x65, 0x46, 0x88, 0x01,
+	0x01, 0x12, 0x36, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x74, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x66, 0x65, 0x65,
+	0x6c, 0x73, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x52, 0x09, 0x66, 0x65, 0x65, 0x6c, 0x73, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x30,
+	0x0a, 0x08, 0x64, 0x65, 0x77, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x64, 0x65, 0x77, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x33, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x64,
+	0x53, 0x70, 0x65, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72,
+	0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x65,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x3f, 0x0a,
+	0x10, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x0f, 0x73,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x54,
+	0x65, 0x78, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x5f, 0x66, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x64, 0x65, 0x77, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x5f, 0x66, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76,
+	0x65, 0x5f, 0x68, 0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x77,
+	0x69, 0x6e, 0x64, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x6d, 0x70, 0x68, 0x42, 0x15, 0x0a,
+	0x13, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x64, 0x65, 0x67, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72,
+	0x65, 0x5f, 0x6d, 0x62, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x5f, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x66, 0x65,
+	0x65, 0x6c, 0x73, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x5f, 0x66, 0x2a, 0x4c, 0x0a, 0x05, 0x55, 0x6e,
+	0x69, 0x74, 0x73, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x4e, 0x49, 0x54, 0x53, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x55, 0x4e,
+	0x49, 0x54, 0x53, 0x5f, 0x55, 0x53, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x55, 0x4e, 0x49, 0x54,
+	0x53, 0x5f, 0x53, 0x49, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x4e, 0x49, 0x54, 0x53, 0x5f,
+	0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x10, 0x03, 0x32, 0x9b, 0x03, 0x0a, 0x0e, 0x57, 0x65, 0x61,
+	0x74, 0x68, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x10, 0x47,
+	0x65, 0x74, 0x54, 0x6f, 0x64, 0x61, 0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12,
+	0x19, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x74,
+	0x4c, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x77, 0x65, 0x61,
+	0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x54, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x44, 0x61, 0x69, 0x6c,
+	0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x12, 0x20, 0x2e, 0x77, 0x65, 0x61, 0x74,
+	0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x46, 0x6f, 0x72, 0x65,
+	0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x77, 0x65,
+	0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x46, 0x6f,
+	0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x57, 0x0a, 0x11, 0x47,
+	0x65, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74,
+	0x12, 0x21, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f,
+	0x75, 0x72, 0x6c, 0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x40, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x65, 0x72,
+	0x74, 0x73, 0x12, 0x19, 0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x6c, 0x65, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4e, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19,
+	0x2e, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x74, 0x4c,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x77, 0x65, 0x61, 0x74,
+	0x68, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x63, 0x67, 0x6c, 0x65, 0x7a, 0x72, 0x65, 0x79, 0x65, 0x73,
+	0x2f, 0x67, 0x6f, 0x5f, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_proto_weather_proto_rawDescOnce sync.Once
+	file_api_proto_weather_proto_rawDescData = file_api_proto_weather_proto_rawDesc
+)
+
+func file_api_proto_weather_proto_rawDescGZIP() []byte {
+	file_api_proto_weather_proto_rawDescOnce.Do(func() {
+		file_api_proto_weather_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proto_weather_proto_rawDescData)
+	})
+	return file_api_proto_weather_proto_rawDescData
+}
+
+var file_api_proto_weather_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_proto_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_api_proto_weather_proto_goTypes = []interface{}{
+	(Units)(0),                    // 0: weather.v1.Units
+	(*Quantity)(nil),              // 1: weather.v1.Quantity
+	(*LatLonRequest)(nil),         // 2: weather.v1.LatLonRequest
+	(*ForecastReply)(nil),         // 3: weather.v1.ForecastReply
+	(*DailyForecastRequest)(nil),  // 4: weather.v1.DailyForecastRequest
+	(*ForecastPeriod)(nil),        // 5: weather.v1.ForecastPeriod
+	(*DailyForecastReply)(nil),    // 6: weather.v1.DailyForecastReply
+	(*HourlyForecastRequest)(nil), // 7: weather.v1.HourlyForecastRequest
+	(*HourlyPeriod)(nil),          // 8: weather.v1.HourlyPeriod
+	(*HourlyForecastReply)(nil),   // 9: weather.v1.HourlyForecastReply
+	(*AlertsRequest)(nil),         // 10: weather.v1.AlertsRequest
+	(*Alert)(nil),                 // 11: weather.v1.Alert
+	(*AlertsReply)(nil),           // 12: weather.v1.AlertsReply
+	(*ConditionsReply)(nil),       // 13: weather.v1.ConditionsReply
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+}
+var file_api_proto_weather_proto_depIdxs = []int32{
+	0,  // 0: weather.v1.LatLonRequest.units:type_name -> weather.v1.Units
+	1,  // 1: weather.v1.ForecastReply.temperature:type_name -> weather.v1.Quantity
+	1,  // 2: weather.v1.ForecastReply.feels_like:type_name -> weather.v1.Quantity
+	1,  // 3: weather.v1.ForecastReply.high:type_name -> weather.v1.Quantity
+	1,  // 4: weather.v1.ForecastReply.low:type_name -> weather.v1.Quantity
+	0,  // 5: weather.v1.DailyForecastRequest.units:type_name -> weather.v1.Units
+	14, // 6: weather.v1.ForecastPeriod.start_time:type_name -> google.protobuf.Timestamp
+	14, // 7: weather.v1.ForecastPeriod.end_time:type_name -> google.protobuf.Timestamp
+	1,  // 8: weather.v1.ForecastPeriod.temperature:type_name -> weather.v1.Quantity
+	5,  // 9: weather.v1.DailyForecastReply.periods:type_name -> weather.v1.ForecastPeriod
+	0,  // 10: weather.v1.HourlyForecastRequest.units:type_name -> weather.v1.Units
+	14, // 11: weather.v1.HourlyPeriod.start_time:type_name -> google.protobuf.Timestamp
+	14, // 12: weather.v1.HourlyPeriod.end_time:type_name -> google.protobuf.Timestamp
+	1,  // 13: weather.v1.HourlyPeriod.temperature:type_name -> weather.v1.Quantity
+	1,  // 14: weather.v1.HourlyPeriod.wind_speed:type_name -> weather.v1.Quantity
+	8,  // 15: weather.v1.HourlyForecastReply.hours:type_name -> weather.v1.HourlyPeriod
+	14, // 16: weather.v1.Alert.onset:type_name -> google.protobuf.Timestamp
+	14, // 17: weather.v1.Alert.expires:type_name -> google.protobuf.Timestamp
+	11, // 18: weather.v1.AlertsReply.alerts:type_name -> weather.v1.Alert
+	14, // 19: weather.v1.ConditionsReply.observed_at:type_name -> google.protobuf.Timestamp
+	1,  // 20: weather.v1.ConditionsReply.temperature:type_name -> weather.v1.Quantity
+	1,  // 21: weather.v1.ConditionsReply.feels_like:type_name -> weather.v1.Quantity
+	1,  // 22: weather.v1.ConditionsReply.dewpoint:type_name -> weather.v1.Quantity
+	1,  // 23: weather.v1.ConditionsReply.wind_speed:type_name -> weather.v1.Quantity
+	1,  // 24: weather.v1.ConditionsReply.pressure:type_name -> weather.v1.Quantity
+	1,  // 25: weather.v1.ConditionsReply.visibility:type_name -> weather.v1.Quantity
+	1,  // 26: weather.v1.ConditionsReply.station_distance:type_name -> weather.v1.Quantity
+	2,  // 27: weather.v1.WeatherService.GetTodayForecast:input_type -> weather.v1.LatLonRequest
+	4,  // 28: weather.v1.WeatherService.GetDailyForecast:input_type -> weather.v1.DailyForecastRequest
+	7,  // 29: weather.v1.WeatherService.GetHourlyForecast:input_type -> weather.v1.HourlyForecastRequest
+	10, // 30: weather.v1.WeatherService.ListAlerts:input_type -> weather.v1.AlertsRequest
+	2,  // 31: weather.v1.WeatherService.GetCurrentConditions:input_type -> weather.v1.LatLonRequest
+	3,  // 32: weather.v1.WeatherService.GetTodayForecast:output_type -> weather.v1.ForecastReply
+	6,  // 33: weather.v1.WeatherService.GetDailyForecast:output_type -> weather.v1.DailyForecastReply
+	9,  // 34: weather.v1.WeatherService.GetHourlyForecast:output_type -> weather.v1.HourlyForecastReply
+	12, // 35: weather.v1.WeatherService.ListAlerts:output_type -> weather.v1.AlertsReply
+	13, // 36: weather.v1.WeatherService.GetCurrentConditions:output_type -> weather.v1.ConditionsReply
+	32, // [32:37] is the sub-list for method output_type
+	27, // [27:32] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_weather_proto_init() }
+func file_api_proto_weather_proto_init() {
+	if File_api_proto_weather_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_proto_weather_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quantity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatLonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForecastReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DailyForecastRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForecastPeriod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DailyForecastReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HourlyForecastRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HourlyPeriod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HourlyForecastReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlertsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alert); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlertsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_weather_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConditionsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -224,18 +1812,21 @@ func file_api_proto_weather_proto_init() {
 			}
 		}
 	}
+	file_api_proto_weather_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_api_proto_weather_proto_msgTypes[12].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_proto_weather_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_proto_weather_proto_goTypes,
 		DependencyIndexes: file_api_proto_weather_proto_depIdxs,
+		EnumInfos:         file_api_proto_weather_proto_enumTypes,
 		MessageInfos:      file_api_proto_weather_proto_msgTypes,
 	}.Build()
 	File_api_proto_weather_proto = out.File
@@ -23,6 +23,10 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type WeatherServiceClient interface {
 	GetTodayForecast(ctx context.Context, in *LatLonRequest, opts ...grpc.CallOption) (*ForecastReply, error)
+	GetDailyForecast(ctx context.Context, in *DailyForecastRequest, opts ...grpc.CallOption) (*DailyForecastReply, error)
+	GetHourlyForecast(ctx context.Context, in *HourlyForecastRequest, opts ...grpc.CallOption) (*HourlyForecastReply, error)
+	ListAlerts(ctx context.Context, in *AlertsRequest, opts ...grpc.CallOption) (*AlertsReply, error)
+	GetCurrentConditions(ctx context.Context, in *LatLonRequest, opts ...grpc.CallOption) (*ConditionsReply, error)
 }
 
 type weatherServiceClient struct {
@@ -42,11 +46,51 @@ func (c *weatherServiceClient) GetTodayForecast(ctx context.Context, in *LatLonR
 	return out, nil
 }
 
+func (c *weatherServiceClient) GetDailyForecast(ctx context.Context, in *DailyForecastRequest, opts ...grpc.CallOption) (*DailyForecastReply, error) {
+	out := new(DailyForecastReply)
+	err := c.cc.Invoke(ctx, "/weather.v1.WeatherService/GetDailyForecast", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weatherServiceClient) GetHourlyForecast(ctx context.Context, in *HourlyForecastRequest, opts ...grpc.CallOption) (*HourlyForecastReply, error) {
+	out := new(HourlyForecastReply)
+	err := c.cc.Invoke(ctx, "/weather.v1.WeatherService/GetHourlyForecast", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weatherServiceClient) ListAlerts(ctx context.Context, in *AlertsRequest, opts ...grpc.CallOption) (*AlertsReply, error) {
+	out := new(AlertsReply)
+	err := c.cc.Invoke(ctx, "/weather.v1.WeatherService/ListAlerts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weatherServiceClient) GetCurrentConditions(ctx context.Context, in *LatLonRequest, opts ...grpc.CallOption) (*ConditionsReply, error) {
+	out := new(ConditionsReply)
+	err := c.cc.Invoke(ctx, "/weather.v1.WeatherService/GetCurrentConditions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WeatherServiceServer is the server API for WeatherService service.
 // All implementations must embed UnimplementedWeatherServiceServer
 // for forward compatibility
 type WeatherServiceServer interface {
 	GetTodayForecast(context.Context, *LatLonRequest) (*ForecastReply, error)
+	GetDailyForecast(context.Context, *DailyForecastRequest) (*DailyForecastReply, error)
+	GetHourlyForecast(context.Context, *HourlyForecastRequest) (*HourlyForecastReply, error)
+	ListAlerts(context.Context, *AlertsRequest) (*AlertsReply, error)
+	GetCurrentConditions(context.Context, *LatLonRequest) (*ConditionsReply, error)
 	mustEmbedUnimplementedWeatherServiceServer()
 }
 
@@ -57,6 +101,18 @@ type UnimplementedWeatherServiceServer struct {
 func (UnimplementedWeatherServiceServer) GetTodayForecast(context.Context, *LatLonRequest) (*ForecastReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTodayForecast not implemented")
 }
+func (UnimplementedWeatherServiceServer) GetDailyForecast(context.Context, *DailyForecastRequest) (*DailyForecastReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailyForecast not implemented")
+}
+func (UnimplementedWeatherServiceServer) GetHourlyForecast(context.Context, *HourlyForecastRequest) (*HourlyForecastReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHourlyForecast not implemented")
+}
+func (UnimplementedWeatherServiceServer) ListAlerts(context.Context, *AlertsRequest) (*AlertsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAlerts not implemented")
+}
+func (UnimplementedWeatherServiceServer) GetCurrentConditions(context.Context, *LatLonRequest) (*ConditionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentConditions not implemented")
+}
 func (UnimplementedWeatherServiceServer) mustEmbedUnimplementedWeatherServiceServer() {}
 
 // UnsafeWeatherServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +144,78 @@ func _WeatherService_GetTodayForecast_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WeatherService_GetDailyForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DailyForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeatherServiceServer).GetDailyForecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/weather.v1.WeatherService/GetDailyForecast",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeatherServiceServer).GetDailyForecast(ctx, req.(*DailyForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeatherService_GetHourlyForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HourlyForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeatherServiceServer).GetHourlyForecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/weather.v1.WeatherService/GetHourlyForecast",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeatherServiceServer).GetHourlyForecast(ctx, req.(*HourlyForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeatherService_ListAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeatherServiceServer).ListAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/weather.v1.WeatherService/ListAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeatherServiceServer).ListAlerts(ctx, req.(*AlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeatherService_GetCurrentConditions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LatLonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeatherServiceServer).GetCurrentConditions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/weather.v1.WeatherService/GetCurrentConditions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeatherServiceServer).GetCurrentConditions(ctx, req.(*LatLonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WeatherService_ServiceDesc is the grpc.ServiceDesc for WeatherService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,6 +227,22 @@ var WeatherService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTodayForecast",
 			Handler:    _WeatherService_GetTodayForecast_Handler,
 		},
+		{
+			MethodName: "GetDailyForecast",
+			Handler:    _WeatherService_GetDailyForecast_Handler,
+		},
+		{
+			MethodName: "GetHourlyForecast",
+			Handler:    _WeatherService_GetHourlyForecast_Handler,
+		},
+		{
+			MethodName: "ListAlerts",
+			Handler:    _WeatherService_ListAlerts_Handler,
+		},
+		{
+			MethodName: "GetCurrentConditions",
+			Handler:    _WeatherService_GetCurrentConditions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/weather.proto",
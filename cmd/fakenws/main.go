@@ -0,0 +1,29 @@
+// Command fakenws serves the nwstest fake of the NWS API for offline
+// development: run the server with NWS_BASE_URL=http://localhost:8081.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/rcglezreyes/go_weather/internal/adapters/nws/nwstest"
+)
+
+func main() {
+	addr := flag.String("addr", ":8081", "listen address")
+	latency := flag.Duration("latency", 0, "delay added to every response")
+	status := flag.Int("status", 0, "fail every request with this status code")
+	malformed := flag.Bool("malformed", false, "serve truncated JSON bodies")
+	flag.Parse()
+
+	n := nwstest.New()
+	n.SetLatency(nwstest.All, *latency)
+	n.SetStatus(nwstest.All, *status)
+	n.SetMalformed(nwstest.All, *malformed)
+
+	log.Printf("fake NWS listening on %s", *addr)
+	srv := &http.Server{Addr: *addr, Handler: n, ReadHeaderTimeout: 5 * time.Second}
+	log.Fatal(srv.ListenAndServe())
+}
@@ -1,17 +1,35 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	_ "time/tzdata" // the runtime image has no zoneinfo; NWS time zones pick "today"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	_ "github.com/rcglezreyes/go_weather/docs" // swagger (si generas con swag)
 
 	grpcadapter "github.com/rcglezreyes/go_weather/internal/adapters/grpc"
 	httpadapter "github.com/rcglezreyes/go_weather/internal/adapters/http"
 	"github.com/rcglezreyes/go_weather/internal/adapters/nws"
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
 	"github.com/rcglezreyes/go_weather/internal/core/usecase"
+	"github.com/rcglezreyes/go_weather/internal/pkg/breaker"
 	"github.com/rcglezreyes/go_weather/internal/pkg/cache"
+	"github.com/rcglezreyes/go_weather/internal/pkg/httpclient"
 	"github.com/rcglezreyes/go_weather/observability/metrics"
 )
 
@@ -26,25 +44,226 @@ func main() {
 
 	metrics.Init()
 
-	// Cache: TTL & janitor
-	c := cache.NewTTLCache(cache.Config{TTL: 300 /*s*/, SweepInterval: 60 /*s*/, MaxEntries: 5000})
+	// Cache: TTL & janitor; forecasts may be served up to 20 min stale when NWS fails
+	forecastCfg := cache.Config{
+		TTL:           getenvInt("CACHE_TTL", 300 /*s*/),
+		StaleTTL:      getenvInt("CACHE_STALE_TTL", 1200 /*s*/),
+		SweepInterval: 60, /*s*/
+		MaxEntries:    getenvInt("CACHE_MAX_ENTRIES", 5000),
+		Policy:        cache.Policy(getenvDefault("CACHE_POLICY", string(cache.PolicyLRU))),
+	}
+	alertsCfg := cache.Config{TTL: 60 /*s*/, SweepInterval: 30 /*s*/, MaxEntries: 5000}
+	// Grid resolution (/points) barely ever changes
+	points := cache.NewTTLCache(cache.Config{TTL: 7 * 24 * 3600 /*s*/, SweepInterval: 3600 /*s*/, MaxEntries: 50000})
+	// Last forecast documents and their validators, to refresh them with
+	// conditional requests
+	validators := cache.NewTTLCache(cache.Config{TTL: 24 * 3600 /*s*/, SweepInterval: 3600 /*s*/, MaxEntries: 10000})
+
+	// In-memory caches are always kept: they are the cache itself or the
+	// fallback of the shared backend
+	forecastLocal := cache.NewTTLCache(forecastCfg)
+	alertsLocal := cache.NewTTLCache(alertsCfg)
+	codec := cache.NewJSONCodec()
+	usecase.RegisterCacheTypes(codec)
+	nws.RegisterCacheTypes(codec)
+	var c, alerts interface {
+		cache.KV
+		cache.Inspector
+	} = forecastLocal, alertsLocal
+	if backend := getenvDefault("CACHE_BACKEND", "memory"); backend == "redis" {
+		c = newRedisCache("forecast", forecastCfg, codec, forecastLocal)
+		alerts = newRedisCache("alerts", alertsCfg, codec, alertsLocal)
+		log.Printf("cache: using redis at %s", getenvDefault("REDIS_ADDR", "localhost:6379"))
+	} else if backend != "memory" {
+		log.Fatalf("unknown CACHE_BACKEND %q", backend)
+	}
+
+	// Administer and measure the caches serving requests, shared or not
+	caches := map[string]cache.Inspector{"forecast": c, "alerts": alerts, "points": points, "validators": validators}
+	for name, ch := range caches {
+		metrics.RegisterCache(name, ch)
+	}
+
+	// Snapshots: restore on startup, save periodically and on shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	snapshotDir := os.Getenv("CACHE_SNAPSHOT_DIR")
+	snapshots := map[string]cache.Snapshotter{"forecast": forecastLocal, "points": points}
+	if snapshotDir != "" {
+		interval := time.Duration(getenvInt("CACHE_SNAPSHOT_INTERVAL", 300 /*s*/)) * time.Second
+		if interval <= 0 {
+			log.Printf("cache: CACHE_SNAPSHOT_INTERVAL is not positive, saving snapshots on shutdown only")
+		}
+		for name, sn := range snapshots {
+			path := filepath.Join(snapshotDir, name+".snap")
+			n, err := cache.LoadSnapshot(path, sn, codec)
+			if err != nil {
+				log.Printf("cache: ignoring snapshot %s: %v", path, err)
+			} else {
+				log.Printf("cache: restored %d %s entries from %s", n, name, path)
+			}
+			go cache.RunSnapshots(ctx, path, interval, sn, codec)
+		}
+	}
 
 	// Adapters + use case
-	nwsClient := nws.NewNWSClient()
-	svc := usecase.NewWeatherService(nwsClient, c)
+	retry := nws.DefaultRetryPolicy
+	retry.MaxAttempts = getenvInt("NWS_RETRY_ATTEMPTS", retry.MaxAttempts)
+	// Circuit breaker: fail fast while NWS is down. Its state is shown by
+	// /readyz and the "nws" gRPC health service but does not make the server
+	// unready: cached forecasts are still served, stale if need be.
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	breakerHealth := grpcadapter.BreakerHealth(hs)
+	br := breaker.New(breaker.Config{
+		FailureThreshold: getenvInt("NWS_BREAKER_THRESHOLD", 5),
+		CoolDown:         time.Duration(getenvInt("NWS_BREAKER_COOLDOWN", 30 /*s*/)) * time.Second,
+		OnStateChange: func(s breaker.State) {
+			metrics.NWSBreakerState.Set(float64(s))
+			breakerHealth(s)
+			log.Printf("nws: circuit breaker %s", s)
+		},
+	})
+	cassetteMode, err := httpclient.ParseCassetteMode(os.Getenv("NWS_CASSETTE_MODE"))
+	if err != nil {
+		log.Fatalf("NWS_CASSETTE_MODE: %v", err)
+	}
+	if cassetteMode != "" {
+		log.Printf("nws: %s mode, cassette %s", cassetteMode, getenvDefault("NWS_CASSETTE_DIR", "cassettes"))
+	}
+	nwsHTTP := httpclient.New(nws.UserAgent, httpclient.WithCassette(cassetteMode, getenvDefault("NWS_CASSETTE_DIR", "cassettes")))
+	nwsClient := nws.NewNWSClient(
+		nws.WithBaseURL(getenvDefault("NWS_BASE_URL", nws.DefaultBaseURL)),
+		nws.WithHTTPClient(nwsHTTP),
+		nws.WithPointsCache(points),
+		nws.WithValidatorCache(validators),
+		nws.WithRetry(retry),
+		nws.WithBreaker(br),
+	)
+	profiles, err := categoryProfiles()
+	if err != nil {
+		log.Fatalf("category profiles: %v", err)
+	}
+	svcOpts := []usecase.Option{usecase.WithAlertsCache(alerts), usecase.WithCategoryProfiles(profiles...)}
+	if os.Getenv("NWS_COVERAGE_CHECK") == "true" {
+		svcOpts = append(svcOpts, usecase.WithCoverage(domain.NWSCoverage))
+	}
+	svc := usecase.NewWeatherService(nwsClient, c, svcOpts...)
+
+	httpOpts := []httpadapter.Option{httpadapter.WithReadinessCheck("nws", func() (any, error) {
+		return br.State().String(), nil
+	})}
+
+	// Warmer: keep the forecasts of the hot locations in the cache
+	locations, err := warmLocations()
+	if err != nil {
+		log.Fatalf("cache warmer: %v", err)
+	}
+	if len(locations) > 0 {
+		w, err := usecase.NewWarmer(svc, usecase.WarmerConfig{
+			Locations:   locations,
+			Interval:    time.Duration(forecastCfg.TTL) * time.Second * 3 / 4,
+			Concurrency: getenvInt("WARM_CONCURRENCY", 4),
+		})
+		if err != nil {
+			log.Fatalf("cache warmer: %v", err)
+		}
+		go w.Run(ctx)
+		httpOpts = append(httpOpts, httpadapter.WithReadinessCheck("warmer", func() (any, error) {
+			st := w.Status()
+			return st, st.Err()
+		}))
+		log.Printf("cache warmer: warming %d locations", len(locations))
+	}
 
-	// gRPC (with Prometheus)
-	if _, err := grpcadapter.Run(":"+*grpcPort, svc); err != nil {
+	// gRPC (with Prometheus); health turns NOT_SERVING on shutdown
+	gs, err := grpcadapter.Run(":"+*grpcPort, svc, grpcadapter.WithHealthServer(hs))
+	if err != nil {
 		log.Fatalf("gRPC: %v", err)
 	}
 	log.Printf("gRPC listening on :%s", *grpcPort)
 
 	// HTTP (Echo + Swagger + /metrics)
-	e := httpadapter.NewEchoServer(svc)
+	httpOpts = append(httpOpts, httpadapter.WithAdminCaches(caches))
+	e := httpadapter.NewEchoServer(svc, httpOpts...)
 	log.Printf("HTTP listening on :%s", *httpPort)
-	if err := e.Start(":" + *httpPort); err != nil {
-		log.Fatal(err)
+	go func() {
+		if err := e.Start(":" + *httpPort); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Printf("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	hs.Shutdown()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP shutdown: %v", err)
 	}
+	gs.GracefulStop()
+	if snapshotDir != "" {
+		for name, sn := range snapshots {
+			path := filepath.Join(snapshotDir, name+".snap")
+			if err := cache.SaveSnapshot(path, sn, codec); err != nil {
+				log.Printf("cache: snapshot %s: %v", path, err)
+			}
+		}
+	}
+}
+
+func newRedisCache(name string, cfg cache.Config, codec cache.Codec, fallback cache.KV) cache.RedisKV {
+	return cache.NewRedisCache(cache.RedisConfig{
+		Addr:      getenvDefault("REDIS_ADDR", "localhost:6379"),
+		Password:  os.Getenv("REDIS_PASSWORD"),
+		DB:        getenvInt("REDIS_DB", 0),
+		KeyPrefix: "go_weather:" + name + ":",
+		TTL:       cfg.TTL,
+		StaleTTL:  cfg.StaleTTL,
+		PoolSize:  getenvInt("REDIS_POOL_SIZE", 10),
+		Codec:     codec,
+		Fallback:  fallback,
+	})
+}
+
+// warmLocations reads "name,lat,lon" lines from WARM_LOCATIONS_FILE and the
+// ";"-separated entries of WARM_LOCATIONS.
+func warmLocations() ([]domain.Location, error) {
+	var locs []domain.Location
+	if path := os.Getenv("WARM_LOCATIONS_FILE"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if locs, err = usecase.ParseLocations(f); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if env := os.Getenv("WARM_LOCATIONS"); env != "" {
+		more, err := usecase.ParseLocations(strings.NewReader(strings.ReplaceAll(env, ";", "\n")))
+		if err != nil {
+			return nil, fmt.Errorf("WARM_LOCATIONS: %w", err)
+		}
+		locs = append(locs, more...)
+	}
+	return locs, nil
+}
+
+// categoryProfiles reads the profiles in CATEGORY_PROFILES_FILE and the
+// ";"-separated ones in CATEGORY_PROFILES; a name may only be defined once.
+func categoryProfiles() ([]domain.CategoryProfile, error) {
+	var src []io.Reader
+	if path := os.Getenv("CATEGORY_PROFILES_FILE"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		src = append(src, f, strings.NewReader("\n"))
+	}
+	src = append(src, strings.NewReader(strings.ReplaceAll(os.Getenv("CATEGORY_PROFILES"), ";", "\n")))
+	return usecase.ParseCategoryProfiles(io.MultiReader(src...))
 }
 
 func getenvDefault(k, def string) string {
@@ -53,3 +272,11 @@ func getenvDefault(k, def string) string {
 	}
 	return def
 }
+
+func getenvInt(k string, def int) int {
+	v, err := strconv.Atoi(os.Getenv(k))
+	if err != nil {
+		return def
+	}
+	return v
+}
@@ -5,19 +5,31 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"log"
+	"math"
 	"net"
 	"os"
+	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
 	grpc_prom "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	weatherv1 "github.com/rcglezreyes/go_weather/api/proto"
+	"github.com/rcglezreyes/go_weather/internal/adapters/i18n"
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
 	"github.com/rcglezreyes/go_weather/internal/core/ports"
+	"github.com/rcglezreyes/go_weather/internal/pkg/breaker"
 )
 
 type server struct {
@@ -28,17 +40,184 @@ type server struct {
 func New(svc ports.WeatherService) *server { return &server{svc: svc} }
 
 func (s *server) GetTodayForecast(ctx context.Context, req *weatherv1.LatLonRequest) (*weatherv1.ForecastReply, error) {
-	res, err := s.svc.GetTodayForecast(ctx, req.GetLat(), req.GetLon())
+	l := localizer(ctx, req.GetLocale())
+	sys, err := unitSystem(req.GetUnits())
 	if err != nil {
-		return nil, err
+		return nil, toStatus(l, err)
+	}
+	res, err := s.svc.GetTodayForecast(ctx, req.GetLat(), req.GetLon(), req.GetProfile())
+	if err != nil {
+		return nil, toStatus(l, err)
 	}
 	return &weatherv1.ForecastReply{
-		ShortForecast: res.ShortForecast,
-		TemperatureF:  res.TemperatureF,
+		ShortForecast: l.Forecast(res.ShortForecast),
+		TemperatureF:  res.Temperature.In(domain.Fahrenheit).Value,
+		FeelsLikeF:    res.FeelsLike.In(domain.Fahrenheit).Value,
 		Category:      res.Category,
+		Stale:         res.Stale,
+		HighF:         domain.ValueIn(res.High, domain.Fahrenheit),
+		LowF:          domain.ValueIn(res.Low, domain.Fahrenheit),
+		Temperature:   quantity(sys, &res.Temperature),
+		FeelsLike:     quantity(sys, &res.FeelsLike),
+		High:          quantity(sys, res.High),
+		Low:           quantity(sys, res.Low),
+		CategoryText:  l.Category(res.Category),
+		Summary:       l.Summary(res, sys),
+	}, nil
+}
+
+func (s *server) GetDailyForecast(ctx context.Context, req *weatherv1.DailyForecastRequest) (*weatherv1.DailyForecastReply, error) {
+	l := localizer(ctx, req.GetLocale())
+	sys, err := unitSystem(req.GetUnits())
+	if err != nil {
+		return nil, toStatus(l, err)
+	}
+	periods, err := s.svc.GetForecast(ctx, req.GetLat(), req.GetLon(), int(req.GetDays()))
+	if err != nil {
+		return nil, toStatus(l, err)
+	}
+	out := &weatherv1.DailyForecastReply{Periods: make([]*weatherv1.ForecastPeriod, 0, len(periods))}
+	for _, p := range periods {
+		out.Periods = append(out.Periods, &weatherv1.ForecastPeriod{
+			Name:             p.Name,
+			StartTime:        timestamppb.New(p.StartTime),
+			EndTime:          timestamppb.New(p.EndTime),
+			IsDaytime:        p.IsDaytime,
+			TemperatureF:     p.Temperature.In(domain.Fahrenheit).Value,
+			ShortForecast:    l.Forecast(p.ShortForecast),
+			DetailedForecast: p.DetailedForecast,
+			Temperature:      quantity(sys, &p.Temperature),
+		})
+	}
+	return out, nil
+}
+
+func (s *server) GetHourlyForecast(ctx context.Context, req *weatherv1.HourlyForecastRequest) (*weatherv1.HourlyForecastReply, error) {
+	l := localizer(ctx, req.GetLocale())
+	sys, err := unitSystem(req.GetUnits())
+	if err != nil {
+		return nil, toStatus(l, err)
+	}
+	periods, err := s.svc.GetHourlyForecast(ctx, req.GetLat(), req.GetLon(), int(req.GetHours()))
+	if err != nil {
+		return nil, toStatus(l, err)
+	}
+	out := &weatherv1.HourlyForecastReply{Hours: make([]*weatherv1.HourlyPeriod, 0, len(periods))}
+	for _, p := range periods {
+		out.Hours = append(out.Hours, &weatherv1.HourlyPeriod{
+			StartTime:                timestamppb.New(p.StartTime),
+			EndTime:                  timestamppb.New(p.EndTime),
+			TemperatureF:             p.Temperature.In(domain.Fahrenheit).Value,
+			WindSpeedMph:             p.WindSpeed.In(domain.MilesPerHour).Value,
+			WindDirection:            p.WindDirection,
+			PrecipitationProbability: p.PrecipitationProbability,
+			ShortForecast:            l.Forecast(p.ShortForecast),
+			Temperature:              quantity(sys, &p.Temperature),
+			WindSpeed:                quantity(sys, &p.WindSpeed),
+		})
+	}
+	return out, nil
+}
+
+func (s *server) ListAlerts(ctx context.Context, req *weatherv1.AlertsRequest) (*weatherv1.AlertsReply, error) {
+	l := localizer(ctx, req.GetLocale())
+	alerts, err := s.svc.ListAlerts(ctx, domain.AlertQuery{Lat: req.GetLat(), Lon: req.GetLon(), Zone: req.GetZone()})
+	if err != nil {
+		return nil, toStatus(l, err)
+	}
+	out := &weatherv1.AlertsReply{Alerts: make([]*weatherv1.Alert, 0, len(alerts))}
+	for _, a := range alerts {
+		out.Alerts = append(out.Alerts, &weatherv1.Alert{
+			Id:            a.ID,
+			Event:         a.Event,
+			Severity:      a.Severity,
+			Urgency:       a.Urgency,
+			Certainty:     a.Certainty,
+			Onset:         timestampOrNil(a.Onset),
+			Expires:       timestampOrNil(a.Expires),
+			Headline:      a.Headline,
+			Description:   a.Description,
+			Instruction:   a.Instruction,
+			AreaDesc:      a.AreaDesc,
+			AffectedZones: a.AffectedZones,
+		})
+	}
+	return out, nil
+}
+
+func (s *server) GetCurrentConditions(ctx context.Context, req *weatherv1.LatLonRequest) (*weatherv1.ConditionsReply, error) {
+	l := localizer(ctx, req.GetLocale())
+	sys, err := unitSystem(req.GetUnits())
+	if err != nil {
+		return nil, toStatus(l, err)
+	}
+	cc, err := s.svc.GetCurrentConditions(ctx, req.GetLat(), req.GetLon(), req.GetProfile())
+	if err != nil {
+		return nil, toStatus(l, err)
+	}
+	return &weatherv1.ConditionsReply{
+		StationId:         cc.StationID,
+		StationName:       cc.StationName,
+		StationDistanceKm: cc.StationDistance.In(domain.Kilometers).Value,
+		ObservedAt:        timestampOrNil(cc.ObservedAt),
+		TextDescription:   l.Forecast(cc.TextDescription),
+		TemperatureF:      domain.ValueIn(cc.Temperature, domain.Fahrenheit),
+		FeelsLikeF:        domain.ValueIn(cc.FeelsLike, domain.Fahrenheit),
+		DewpointF:         domain.ValueIn(cc.Dewpoint, domain.Fahrenheit),
+		RelativeHumidity:  cc.RelativeHumidity,
+		WindSpeedMph:      domain.ValueIn(cc.WindSpeed, domain.MilesPerHour),
+		WindDirectionDeg:  cc.WindDirectionDeg,
+		PressureMb:        domain.ValueIn(cc.Pressure, domain.Millibars),
+		VisibilityMiles:   domain.ValueIn(cc.Visibility, domain.Miles),
+		Category:          cc.Category,
+		Temperature:       quantity(sys, cc.Temperature),
+		FeelsLike:         quantity(sys, cc.FeelsLike),
+		Dewpoint:          quantity(sys, cc.Dewpoint),
+		WindSpeed:         quantity(sys, cc.WindSpeed),
+		Pressure:          quantity(sys, cc.Pressure),
+		Visibility:        quantity(sys, cc.Visibility),
+		StationDistance:   quantity(sys, &cc.StationDistance),
+		CategoryText:      l.Category(cc.Category),
 	}, nil
 }
 
+// localizer picks the catalog for the locale of a request and reports it
+// in the content-language response header.
+func localizer(ctx context.Context, locale string) *i18n.Localizer {
+	l := i18n.Match(locale)
+	_ = grpc.SetHeader(ctx, metadata.Pairs("content-language", l.Locale()))
+	return l
+}
+
+func unitSystem(u weatherv1.Units) (domain.UnitSystem, error) {
+	switch u {
+	case weatherv1.Units_UNITS_UNSPECIFIED, weatherv1.Units_UNITS_US:
+		return domain.US, nil
+	case weatherv1.Units_UNITS_SI:
+		return domain.SI, nil
+	case weatherv1.Units_UNITS_METRIC:
+		return domain.Metric, nil
+	}
+	return domain.ParseUnitSystem(u.String())
+}
+
+// quantity converts an optional quantity to the unit system of the request,
+// rounded to hundredths so conversions do not leak float noise.
+func quantity(sys domain.UnitSystem, q *domain.Quantity) *weatherv1.Quantity {
+	if q == nil {
+		return nil
+	}
+	c := sys.Convert(*q)
+	return &weatherv1.Quantity{Value: math.Round(c.Value*100) / 100, Unit: string(c.Unit)}
+}
+
+func timestampOrNil(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
 func tlsConfigFromEnv() (grpc.ServerOption, bool, error) {
 	certFile := os.Getenv("GRPC_TLS_CERT")
 	keyFile := os.Getenv("GRPC_TLS_KEY")
@@ -64,7 +243,89 @@ func tlsConfigFromEnv() (grpc.ServerOption, bool, error) {
 	return grpc.Creds(credentials.NewTLS(tlsCfg)), true, nil
 }
 
-func Run(addr string, s ports.WeatherService) (*grpc.Server, error) {
+// toStatus maps service errors to the gRPC codes matching the statuses the
+// HTTP adapter gives them: InvalidArgument (400), NotFound (404),
+// ResourceExhausted (429), Unavailable (503), DeadlineExceeded (504) and,
+// for bad provider responses (502), Unavailable, which is what gRPC's own
+// HTTP mapping makes of a 502. Canceled requests are Canceled and other
+// errors Unknown.
+//
+// Only invalid arguments are described in the status message; for anything
+// else it is the generic English message and the cause is logged, so that
+// provider errors do not reach clients. A LocalizedMessage detail, and the
+// BadRequest descriptions, are in the locale of the request.
+func toStatus(l *i18n.Localizer, err error) error {
+	details := []protoadapt.MessageV1{&errdetails.LocalizedMessage{Locale: l.Locale(), Message: l.Error(err)}}
+	code := codes.Unknown
+	var verr *domain.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		br := &errdetails.BadRequest{}
+		for _, v := range verr.Violations {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: l.Violation(v)})
+		}
+		code, details = codes.InvalidArgument, append(details, br)
+	case errors.Is(err, domain.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrRateLimited):
+		code = codes.ResourceExhausted
+	case errors.Is(err, domain.ErrUpstreamUnavailable), errors.Is(err, domain.ErrBadUpstreamData):
+		code = codes.Unavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	}
+	msg := err.Error()
+	if code != codes.InvalidArgument {
+		log.Printf("grpc: %v", err)
+		msg = i18n.Match(i18n.Default).Error(err)
+	}
+	st, derr := status.New(code, msg).WithDetails(details...)
+	if derr != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// NWSHealthService is the health service that follows the NWS circuit
+// breaker. The overall "" service stays SERVING meanwhile, since cached
+// forecasts are still served.
+const NWSHealthService = "nws"
+
+// BreakerHealth returns a breaker OnStateChange hook setting
+// NWSHealthService in hs NOT_SERVING while the breaker is open and SERVING
+// again once it closes.
+func BreakerHealth(hs *health.Server) func(breaker.State) {
+	hs.SetServingStatus(NWSHealthService, healthpb.HealthCheckResponse_SERVING)
+	return func(s breaker.State) {
+		switch s {
+		case breaker.Open:
+			hs.SetServingStatus(NWSHealthService, healthpb.HealthCheckResponse_NOT_SERVING)
+		case breaker.Closed:
+			hs.SetServingStatus(NWSHealthService, healthpb.HealthCheckResponse_SERVING)
+		}
+	}
+}
+
+type options struct {
+	health *health.Server
+}
+
+type Option func(*options)
+
+// WithHealthServer registers hs as the health service instead of one that
+// always reports SERVING, so that the caller can report its own status.
+func WithHealthServer(hs *health.Server) Option {
+	return func(o *options) { o.health = hs }
+}
+
+func Run(addr string, s ports.WeatherService, opts ...Option) (*grpc.Server, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		return nil, err
@@ -80,21 +341,24 @@ func Run(addr string, s ports.WeatherService) (*grpc.Server, error) {
 	if err != nil {
 		return nil, err
 	}
-	var opts []grpc.ServerOption
+	var srvOpts []grpc.ServerOption
 	if hasTLS {
-		opts = append(opts, tlsOpt)
+		srvOpts = append(srvOpts, tlsOpt)
 	}
 
-	opts = append(opts, grpc.ChainUnaryInterceptor(uInts...))
+	srvOpts = append(srvOpts, grpc.ChainUnaryInterceptor(uInts...))
 
-	gs := grpc.NewServer(opts...)
+	gs := grpc.NewServer(srvOpts...)
 
 	weatherv1.RegisterWeatherServiceServer(gs, New(s))
 
 	// Health + Reflection
-	hs := health.NewServer()
+	hs := o.health
+	if hs == nil {
+		hs = health.NewServer()
+		hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	}
 	healthpb.RegisterHealthServer(gs, hs)
-	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 	reflection.Register(gs)
 
 	// Prometheus metrics
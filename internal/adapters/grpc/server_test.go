@@ -2,22 +2,56 @@ package grpcadapter
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"net"
+	"strings"
 	"testing"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
 	weatherv1 "github.com/rcglezreyes/go_weather/api/proto"
+	"github.com/rcglezreyes/go_weather/internal/adapters/i18n"
 	"github.com/rcglezreyes/go_weather/internal/core/domain"
+	"github.com/rcglezreyes/go_weather/internal/pkg/breaker"
 )
 
 type fakeSvc struct{}
 
-func (fakeSvc) GetTodayForecast(ctx context.Context, lat, lon float64) (domain.TodayForecast, error) {
-	return domain.TodayForecast{ShortForecast: "Sunny", TemperatureF: 75, Category: "moderate"}, nil
+func (fakeSvc) GetTodayForecast(ctx context.Context, lat, lon float64, profile string) (domain.TodayForecast, error) {
+	return domain.TodayForecast{ShortForecast: "Sunny", Temperature: domain.Quantity{Value: 77, Unit: domain.Fahrenheit}, Category: "moderate"}, nil
 }
 
+func (fakeSvc) GetForecast(ctx context.Context, lat, lon float64, days int) ([]domain.ForecastPeriod, error) {
+	start := time.Date(2024, 7, 1, 6, 0, 0, 0, time.UTC)
+	return []domain.ForecastPeriod{
+		{Name: "Today", StartTime: start, EndTime: start.Add(12 * time.Hour), IsDaytime: true, Temperature: domain.Quantity{Value: 88, Unit: domain.Fahrenheit}, ShortForecast: "Sunny"},
+		{Name: "Tonight", StartTime: start.Add(12 * time.Hour), EndTime: start.Add(24 * time.Hour), Temperature: domain.Quantity{Value: 70, Unit: domain.Fahrenheit}, ShortForecast: "Clear"},
+	}, nil
+}
+
+func (fakeSvc) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) ([]domain.HourlyPeriod, error) {
+	return nil, nil
+}
+
+func (fakeSvc) ListAlerts(ctx context.Context, q domain.AlertQuery) ([]domain.Alert, error) {
+	return nil, nil
+}
+
+func (fakeSvc) GetCurrentConditions(ctx context.Context, lat, lon float64, profile string) (domain.CurrentConditions, error) {
+	return domain.CurrentConditions{}, nil
+}
+
+func (fakeSvc) CategoryProfiles() []domain.CategoryProfile { return nil }
+
 func dialer(gs *grpc.Server) func(context.Context, string) (net.Conn, error) {
 	lis := bufconn.Listen(1024 * 1024)
 	go func() { _ = gs.Serve(lis) }()
@@ -39,7 +73,119 @@ func TestGRPC_GetTodayForecast(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if got.GetCategory() != "moderate" {
-		t.Fatalf("want moderate, got %s", got.GetCategory())
+	if got.GetCategory() != "moderate" || got.GetTemperatureF() != 77 || got.GetTemperature().GetUnit() != "F" {
+		t.Fatalf("want moderate 77°F, got %v", got)
+	}
+
+	got, err = cli.GetTodayForecast(context.Background(), &weatherv1.LatLonRequest{Lat: 1, Lon: 2, Units: weatherv1.Units_UNITS_METRIC})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := got.GetTemperature(); q.GetUnit() != "C" || q.GetValue() != 25 || got.GetTemperatureF() != 77 {
+		t.Fatalf("want 25°C, got %v", got)
+	}
+	if _, err := cli.GetTodayForecast(context.Background(), &weatherv1.LatLonRequest{Units: 42}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("want InvalidArgument for unknown units, got %v", err)
+	}
+
+	got, err = cli.GetTodayForecast(context.Background(), &weatherv1.LatLonRequest{Lat: 1, Lon: 2, Units: weatherv1.Units_UNITS_SI, Locale: "es"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetShortForecast() != "Soleado" || got.GetCategory() != "moderate" || got.GetCategoryText() != "moderado" ||
+		got.GetSummary() != "Soleado. 25 °C (moderado)." {
+		t.Fatalf("want a Spanish forecast, got %v", got)
+	}
+}
+
+func TestGRPC_GetDailyForecast(t *testing.T) {
+	gs := grpc.NewServer()
+	weatherv1.RegisterWeatherServiceServer(gs, New(fakeSvc{}))
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(dialer(gs)), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	cli := weatherv1.NewWeatherServiceClient(conn)
+	got, err := cli.GetDailyForecast(context.Background(), &weatherv1.DailyForecastRequest{Lat: 1, Lon: 2, Days: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.GetPeriods()) != 2 {
+		t.Fatalf("want 2 periods, got %d", len(got.GetPeriods()))
+	}
+	if p := got.GetPeriods()[0]; p.GetName() != "Today" || !p.GetIsDaytime() || p.GetStartTime().AsTime().Hour() != 6 {
+		t.Fatalf("unexpected first period: %v", p)
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	cases := []struct {
+		err  error
+		want codes.Code
+	}{
+		{fmt.Errorf("nws points: 404: %w", domain.ErrNotFound), codes.NotFound},
+		{fmt.Errorf("nws forecast: 429: %w", domain.ErrRateLimited), codes.ResourceExhausted},
+		{fmt.Errorf("nws: %w", domain.ErrUpstreamUnavailable), codes.Unavailable},
+		{fmt.Errorf("nws points: %w", domain.ErrBadUpstreamData), codes.Unavailable},
+		{fmt.Errorf("nws forecast: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{fmt.Errorf("nws forecast: %w", context.Canceled), codes.Canceled},
+		{errors.New("boom"), codes.Unknown},
+	}
+	for _, tc := range cases {
+		st := status.Convert(toStatus(i18n.Match(""), tc.err))
+		if st.Code() != tc.want {
+			t.Errorf("%v: want %v, got %v", tc.err, tc.want, st.Code())
+		}
+		if strings.Contains(st.Message(), "nws") || strings.Contains(st.Message(), "boom") {
+			t.Errorf("%v: the cause leaks into the status message %q", tc.err, st.Message())
+		}
+	}
+}
+
+func TestToStatus_FieldViolations(t *testing.T) {
+	_, _, err := domain.NormalizeLatLon(math.NaN(), 500, nil)
+	st := status.Convert(toStatus(i18n.Match("es-MX"), err))
+	if st.Code() != codes.InvalidArgument || st.Message() != err.Error() || len(st.Details()) != 2 {
+		t.Fatalf("want InvalidArgument with details, got %v", st)
+	}
+	lm, ok := st.Details()[0].(*errdetails.LocalizedMessage)
+	if !ok || lm.GetLocale() != "es" {
+		t.Fatalf("unexpected localized message %v", st.Details()[0])
+	}
+	br, ok := st.Details()[1].(*errdetails.BadRequest)
+	if !ok || len(br.GetFieldViolations()) != 2 || br.GetFieldViolations()[0].GetField() != "lat" ||
+		br.GetFieldViolations()[0].GetDescription() != "debe ser un número finito" {
+		t.Fatalf("unexpected details %v", st.Details())
+	}
+}
+
+func TestBreakerHealth(t *testing.T) {
+	hs := health.NewServer()
+	onChange := BreakerHealth(hs)
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		res, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.GetStatus()
+	}
+	for _, tc := range []struct {
+		state breaker.State
+		want  healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{breaker.Open, healthpb.HealthCheckResponse_NOT_SERVING},
+		{breaker.HalfOpen, healthpb.HealthCheckResponse_NOT_SERVING},
+		{breaker.Closed, healthpb.HealthCheckResponse_SERVING},
+	} {
+		onChange(tc.state)
+		if got := check(NWSHealthService); got != tc.want {
+			t.Errorf("%s: want %s, got %s", tc.state, tc.want, got)
+		}
+		if got := check(""); got != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("%s: want the server SERVING, got %s", tc.state, got)
+		}
 	}
 }
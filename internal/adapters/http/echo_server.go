@@ -9,9 +9,38 @@ import (
 	"github.com/rcglezreyes/go_weather/internal/adapters/http/handlers"
 	apikey "github.com/rcglezreyes/go_weather/internal/adapters/http/middleware/apikey"
 	"github.com/rcglezreyes/go_weather/internal/core/ports"
+	"github.com/rcglezreyes/go_weather/internal/pkg/cache"
 )
 
-func NewEchoServer(svc ports.WeatherService) *echo.Echo {
+type options struct {
+	caches map[string]cache.Inspector
+	checks map[string]handlers.ReadinessCheck
+}
+
+type Option func(*options)
+
+// WithAdminCaches exposes the caches under /admin, guarded by ADMIN_API_KEY.
+func WithAdminCaches(caches map[string]cache.Inspector) Option {
+	return func(o *options) { o.caches = caches }
+}
+
+// WithReadinessCheck makes /readyz report check under name and fail while it
+// returns an error.
+func WithReadinessCheck(name string, check handlers.ReadinessCheck) Option {
+	return func(o *options) {
+		if o.checks == nil {
+			o.checks = map[string]handlers.ReadinessCheck{}
+		}
+		o.checks[name] = check
+	}
+}
+
+func NewEchoServer(svc ports.WeatherService, opts ...Option) *echo.Echo {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	e := echo.New()
 
 	//Global middleware
@@ -25,8 +54,9 @@ func NewEchoServer(svc ports.WeatherService) *echo.Echo {
 	e.Use(apikey.OptionalCheckerFromEnv())
 
 	// Health
-	e.GET("/healthz", func(c echo.Context) error { return c.NoContent(200) })
-	e.GET("/readyz", func(c echo.Context) error { return c.NoContent(200) })
+	hh := handlers.NewHealthHandler(o.checks)
+	e.GET("/healthz", hh.Healthz)
+	e.GET("/readyz", hh.Readyz)
 
 	// Prometheus metrics
 	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
@@ -35,6 +65,22 @@ func NewEchoServer(svc ports.WeatherService) *echo.Echo {
 	h := handlers.NewWeatherHandler(svc)
 	v1 := e.Group("/api/v1")
 	v1.GET("/forecast", h.GetTodayForecast)
+	v1.GET("/forecast/daily", h.GetDailyForecast)
+	v1.GET("/forecast/hourly", h.GetHourlyForecast)
+	v1.GET("/alerts", h.ListAlerts)
+	v1.GET("/conditions", h.GetCurrentConditions)
+	v1.GET("/categories", h.ListCategories)
+
+	// Admin
+	if len(o.caches) > 0 {
+		ah := handlers.NewAdminHandler(o.caches)
+		admin := e.Group("/admin", apikey.RequiredCheckerFromEnv("ADMIN_API_KEY"))
+		admin.GET("/caches", ah.ListCaches)
+		admin.GET("/caches/:name/keys", ah.ListKeys)
+		admin.GET("/caches/:name/keys/:key", ah.GetEntry)
+		admin.DELETE("/caches/:name/keys/:key", ah.DeleteEntry)
+		admin.DELETE("/caches/:name", ah.PurgeCache)
+	}
 
 	// Swagger
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+
+	echo "github.com/labstack/echo/v4"
+
+	"github.com/rcglezreyes/go_weather/internal/pkg/cache"
+)
+
+type AdminHandler struct{ caches map[string]cache.Inspector }
+
+func NewAdminHandler(caches map[string]cache.Inspector) *AdminHandler {
+	return &AdminHandler{caches: caches}
+}
+
+// ListCaches godoc
+// @Summary List caches and their statistics
+// @Produce json
+// @Param X-Admin-Key header string true "Admin key"
+// @Success 200 {object} map[string]cache.Stats
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/caches [get]
+func (h *AdminHandler) ListCaches(c echo.Context) error {
+	out := make(map[string]cache.Stats, len(h.caches))
+	for name, ch := range h.caches {
+		out[name] = ch.Stats()
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// ListKeys godoc
+// @Summary List cached keys with their remaining TTL
+// @Produce json
+// @Param X-Admin-Key header string true "Admin key"
+// @Param name path string true "Cache name"
+// @Success 200 {array} cache.EntryInfo
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/caches/{name}/keys [get]
+func (h *AdminHandler) ListKeys(c echo.Context) error {
+	ch, ok := h.caches[c.Param("name")]
+	if !ok {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Message: "unknown cache"})
+	}
+	keys := ch.Keys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Key < keys[j].Key })
+	return c.JSON(http.StatusOK, keys)
+}
+
+// GetEntry godoc
+// @Summary Inspect one cached entry
+// @Produce json
+// @Param X-Admin-Key header string true "Admin key"
+// @Param name path string true "Cache name"
+// @Param key path string true "Cache key (URL-encoded)"
+// @Success 200 {object} cache.EntryInfo
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/caches/{name}/keys/{key} [get]
+func (h *AdminHandler) GetEntry(c echo.Context) error {
+	ch, key, err := h.lookup(c)
+	if err != nil {
+		return err
+	}
+	info, ok := ch.Entry(key)
+	if !ok {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Message: "key not found"})
+	}
+	return c.JSON(http.StatusOK, info)
+}
+
+// DeleteEntry godoc
+// @Summary Purge one cached entry
+// @Param X-Admin-Key header string true "Admin key"
+// @Param name path string true "Cache name"
+// @Param key path string true "Cache key (URL-encoded)"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/caches/{name}/keys/{key} [delete]
+func (h *AdminHandler) DeleteEntry(c echo.Context) error {
+	ch, key, err := h.lookup(c)
+	if err != nil {
+		return err
+	}
+	if !ch.Delete(key) {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Message: "key not found"})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PurgeCache godoc
+// @Summary Purge a whole cache
+// @Param X-Admin-Key header string true "Admin key"
+// @Param name path string true "Cache name"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/caches/{name} [delete]
+func (h *AdminHandler) PurgeCache(c echo.Context) error {
+	ch, ok := h.caches[c.Param("name")]
+	if !ok {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Message: "unknown cache"})
+	}
+	ch.Purge()
+	return c.NoContent(http.StatusNoContent)
+}
+
+// lookup resolves the cache and the unescaped key of the request.
+func (h *AdminHandler) lookup(c echo.Context) (cache.Inspector, string, error) {
+	ch, ok := h.caches[c.Param("name")]
+	if !ok {
+		return nil, "", echo.NewHTTPError(http.StatusNotFound, "unknown cache")
+	}
+	key, err := url.PathUnescape(c.Param("key"))
+	if err != nil {
+		return nil, "", echo.NewHTTPError(http.StatusBadRequest, "invalid key")
+	}
+	return ch, key, nil
+}
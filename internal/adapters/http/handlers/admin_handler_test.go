@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	echo "github.com/labstack/echo/v4"
+
+	apikey "github.com/rcglezreyes/go_weather/internal/adapters/http/middleware/apikey"
+	"github.com/rcglezreyes/go_weather/internal/pkg/cache"
+)
+
+// newAdmin routes the admin API like the server does, guarded by the key
+// in ADMIN_API_KEY.
+func newAdmin(caches map[string]cache.Inspector) *echo.Echo {
+	h := NewAdminHandler(caches)
+	e := echo.New()
+	admin := e.Group("/admin", apikey.RequiredCheckerFromEnv("ADMIN_API_KEY"))
+	admin.GET("/caches", h.ListCaches)
+	admin.GET("/caches/:name/keys", h.ListKeys)
+	admin.GET("/caches/:name/keys/:key", h.GetEntry)
+	admin.DELETE("/caches/:name/keys/:key", h.DeleteEntry)
+	admin.DELETE("/caches/:name", h.PurgeCache)
+	return e
+}
+
+func do(e *echo.Echo, method, target, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	if key != "" {
+		req.Header.Set("X-Admin-Key", key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAdmin_KeyGuard(t *testing.T) {
+	caches := map[string]cache.Inspector{"forecast": cache.NewTTLCache(cache.Config{TTL: 60})}
+
+	t.Setenv("ADMIN_API_KEY", "")
+	if rec := do(newAdmin(caches), http.MethodGet, "/admin/caches", "anything"); rec.Code != http.StatusForbidden {
+		t.Errorf("without ADMIN_API_KEY: status = %d, want 403", rec.Code)
+	}
+
+	t.Setenv("ADMIN_API_KEY", "s3cret")
+	e := newAdmin(caches)
+	for _, key := range []string{"", "wrong", "s3cret "} {
+		if rec := do(e, http.MethodDelete, "/admin/caches/forecast", key); rec.Code != http.StatusUnauthorized {
+			t.Errorf("key %q: status = %d, want 401", key, rec.Code)
+		}
+	}
+	if rec := do(e, http.MethodGet, "/admin/caches", "s3cret"); rec.Code != http.StatusOK {
+		t.Errorf("valid key: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestAdmin_Entries(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "s3cret")
+	forecast := cache.NewTTLCache(cache.Config{TTL: 60})
+	forecast.Set("today:39.74,-104.99", "Sunny")
+	forecast.Set("today:40.71,-74.01", "Rain")
+	e := newAdmin(map[string]cache.Inspector{"forecast": forecast})
+
+	keys := decode[[]cache.EntryInfo](t, do(e, http.MethodGet, "/admin/caches/forecast/keys", "s3cret"))
+	if len(keys) != 2 || keys[0].Key != "today:39.74,-104.99" || keys[0].TTL <= 0 {
+		t.Fatalf("keys = %+v", keys)
+	}
+
+	entry := "/admin/caches/forecast/keys/" + url.PathEscape("today:39.74,-104.99")
+	if info := decode[cache.EntryInfo](t, do(e, http.MethodGet, entry, "s3cret")); info.Value != "Sunny" {
+		t.Errorf("entry = %+v", info)
+	}
+	if rec := do(e, http.MethodDelete, entry, "s3cret"); rec.Code != http.StatusNoContent {
+		t.Errorf("delete: status = %d", rec.Code)
+	}
+	if rec := do(e, http.MethodDelete, entry, "s3cret"); rec.Code != http.StatusNotFound {
+		t.Errorf("second delete: status = %d, want 404", rec.Code)
+	}
+
+	if rec := do(e, http.MethodDelete, "/admin/caches/alerts", "s3cret"); rec.Code != http.StatusNotFound {
+		t.Errorf("purge of an unknown cache: status = %d, want 404", rec.Code)
+	}
+	if rec := do(e, http.MethodDelete, "/admin/caches/forecast", "s3cret"); rec.Code != http.StatusNoContent {
+		t.Errorf("purge: status = %d", rec.Code)
+	}
+	if n := forecast.Stats().Entries; n != 0 {
+		t.Errorf("%d entries left after purge", n)
+	}
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	echo "github.com/labstack/echo/v4"
+)
+
+// ReadinessCheck reports a component's state; a non-nil error makes the
+// server not ready.
+type ReadinessCheck func() (detail any, err error)
+
+type HealthHandler struct{ checks map[string]ReadinessCheck }
+
+func NewHealthHandler(checks map[string]ReadinessCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+type CheckResponse struct {
+	Ready  bool   `json:"ready"`
+	Error  string `json:"error,omitempty"`
+	Detail any    `json:"detail,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Ready  bool                     `json:"ready"`
+	Checks map[string]CheckResponse `json:"checks,omitempty"`
+}
+
+// Healthz reports that the process is up.
+func (h *HealthHandler) Healthz(c echo.Context) error { return c.NoContent(http.StatusOK) }
+
+// Readyz godoc
+// @Summary Readiness with the state of each component
+// @Produce json
+// @Success 200 {object} ReadinessResponse
+// @Failure 503 {object} ReadinessResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c echo.Context) error {
+	if len(h.checks) == 0 {
+		return c.NoContent(http.StatusOK)
+	}
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := ReadinessResponse{Ready: true, Checks: make(map[string]CheckResponse, len(names))}
+	for _, name := range names {
+		detail, err := h.checks[name]()
+		cr := CheckResponse{Ready: err == nil, Detail: detail}
+		if err != nil {
+			cr.Error = err.Error()
+			res.Ready = false
+		}
+		res.Checks[name] = cr
+	}
+	status := http.StatusOK
+	if !res.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	return c.JSON(status, res)
+}
@@ -1,10 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
+	"time"
 
 	echo "github.com/labstack/echo/v4"
+	"github.com/rcglezreyes/go_weather/internal/adapters/i18n"
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
 	"github.com/rcglezreyes/go_weather/internal/core/ports"
 )
 
@@ -14,53 +20,513 @@ func NewWeatherHandler(svc ports.WeatherService) *WeatherHandler { return &Weath
 
 // GetTodayForecast godoc
 // @Summary Get today's short forecast and temperature category
-// @Description Returns today's short forecast and temperature category using NWS
+// @Description Returns the current short forecast and temperature category, today's high and tonight's low using NWS, and a summary of them in the language of the request
 // @Param lat query number true "Latitude"
 // @Param lon query number true "Longitude"
+// @Param profile query string false "Category profile, see /categories"
+// @Param units query string false "Unit system: us (default), si or metric"
+// @Param Accept-Language header string false "Language of texts and messages: en (default) or es"
 // @Produce json
 // @Success 200 {object} ForecastResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Failure 504 {object} ErrorResponse
 // @Router /forecast [get]
 func (h *WeatherHandler) GetTodayForecast(c echo.Context) error {
 	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
-	latStr := c.QueryParam("lat")
-	lonStr := c.QueryParam("lon")
+	lat, lon, err := parseLatLon(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	sys, err := domain.ParseUnitSystem(c.QueryParam("units"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	res, err := h.svc.GetTodayForecast(c.Request().Context(), lat, lon, c.QueryParam("profile"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	l := localizer(c)
+
+	if res.Stale {
+		c.Response().Header().Set("X-Cache", "STALE")
+		c.Response().Header().Set("Warning", `110 - "Response is Stale"`)
+	}
+	return c.JSON(http.StatusOK, ForecastResponse{
+		ShortForecast: l.Forecast(res.ShortForecast),
+		TemperatureF:  res.Temperature.In(domain.Fahrenheit).Value,
+		FeelsLikeF:    res.FeelsLike.In(domain.Fahrenheit).Value,
+		HighF:         domain.ValueIn(res.High, domain.Fahrenheit),
+		LowF:          domain.ValueIn(res.Low, domain.Fahrenheit),
+		Temperature:   quantity(sys, &res.Temperature),
+		FeelsLike:     quantity(sys, &res.FeelsLike),
+		High:          quantity(sys, res.High),
+		Low:           quantity(sys, res.Low),
+		Category:      res.Category,
+		CategoryText:  l.Category(res.Category),
+		Summary:       l.Summary(res, sys),
+		Stale:         res.Stale,
+	})
+}
+
+// GetDailyForecast godoc
+// @Summary Get the multi-day forecast
+// @Description Returns the day/night forecast periods for up to 7 days using NWS
+// @Param lat query number true "Latitude"
+// @Param lon query number true "Longitude"
+// @Param days query int false "Number of days (1-7, default 7)"
+// @Param units query string false "Unit system: us (default), si or metric"
+// @Param Accept-Language header string false "Language of texts and messages: en (default) or es"
+// @Produce json
+// @Success 200 {object} DailyForecastResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Failure 504 {object} ErrorResponse
+// @Router /forecast/daily [get]
+func (h *WeatherHandler) GetDailyForecast(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	lat, lon, err := parseLatLon(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	sys, err := domain.ParseUnitSystem(c.QueryParam("units"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	days := domain.MaxForecastDays
+	if v := c.QueryParam("days"); v != "" {
+		days, err = strconv.Atoi(v)
+		if err != nil || days < 1 || days > domain.MaxForecastDays {
+			return errorResponse(c, invalidField("days", "must be between 1 and %d", domain.MaxForecastDays))
+		}
+	}
+
+	periods, err := h.svc.GetForecast(c.Request().Context(), lat, lon, days)
+	if err != nil {
+		return errorResponse(c, err)
+	}
 
-	lat, err := strconv.ParseFloat(latStr, 64)
+	l := localizer(c)
+	out := DailyForecastResponse{Periods: make([]ForecastPeriodResponse, 0, len(periods))}
+	for _, p := range periods {
+		out.Periods = append(out.Periods, ForecastPeriodResponse{
+			Name:             p.Name,
+			StartTime:        p.StartTime,
+			EndTime:          p.EndTime,
+			IsDaytime:        p.IsDaytime,
+			TemperatureF:     p.Temperature.In(domain.Fahrenheit).Value,
+			Temperature:      quantity(sys, &p.Temperature),
+			ShortForecast:    l.Forecast(p.ShortForecast),
+			DetailedForecast: p.DetailedForecast,
+		})
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// GetHourlyForecast godoc
+// @Summary Get the hourly forecast
+// @Description Returns temperature, wind and precipitation probability per hour using NWS
+// @Param lat query number true "Latitude"
+// @Param lon query number true "Longitude"
+// @Param hours query int false "Number of hours (1-156, default 24)"
+// @Param units query string false "Unit system: us (default), si or metric"
+// @Param Accept-Language header string false "Language of texts and messages: en (default) or es"
+// @Produce json
+// @Success 200 {object} HourlyForecastResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Failure 504 {object} ErrorResponse
+// @Router /forecast/hourly [get]
+func (h *WeatherHandler) GetHourlyForecast(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	lat, lon, err := parseLatLon(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	sys, err := domain.ParseUnitSystem(c.QueryParam("units"))
 	if err != nil {
-		c.Logger().Error(err)
-		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: "invalid lat"})
+		return errorResponse(c, err)
 	}
-	lon, err := strconv.ParseFloat(lonStr, 64)
+
+	hours := domain.DefaultForecastHours
+	if v := c.QueryParam("hours"); v != "" {
+		hours, err = strconv.Atoi(v)
+		if err != nil || hours < 1 || hours > domain.MaxForecastHours {
+			return errorResponse(c, invalidField("hours", "must be between 1 and %d", domain.MaxForecastHours))
+		}
+	}
+
+	periods, err := h.svc.GetHourlyForecast(c.Request().Context(), lat, lon, hours)
 	if err != nil {
-		c.Logger().Error(err)
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Message: "invalid lon",
+		return errorResponse(c, err)
+	}
+
+	l := localizer(c)
+	out := HourlyForecastResponse{Hours: make([]HourlyPeriodResponse, 0, len(periods))}
+	for _, p := range periods {
+		out.Hours = append(out.Hours, HourlyPeriodResponse{
+			StartTime:                p.StartTime,
+			EndTime:                  p.EndTime,
+			TemperatureF:             p.Temperature.In(domain.Fahrenheit).Value,
+			WindSpeedMph:             p.WindSpeed.In(domain.MilesPerHour).Value,
+			Temperature:              quantity(sys, &p.Temperature),
+			WindSpeed:                quantity(sys, &p.WindSpeed),
+			WindDirection:            p.WindDirection,
+			PrecipitationProbability: p.PrecipitationProbability,
+			ShortForecast:            l.Forecast(p.ShortForecast),
 		})
 	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// ListAlerts godoc
+// @Summary List active weather alerts
+// @Description Returns the active NWS watches, warnings and advisories for a forecast zone or a point
+// @Param lat query number false "Latitude (required without zone)"
+// @Param lon query number false "Longitude (required without zone)"
+// @Param zone query string false "NWS forecast zone, e.g. MDZ011"
+// @Param Accept-Language header string false "Language of error messages: en (default) or es"
+// @Produce json
+// @Success 200 {object} AlertsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Failure 504 {object} ErrorResponse
+// @Router /alerts [get]
+func (h *WeatherHandler) ListAlerts(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	var q domain.AlertQuery
+	if zone := c.QueryParam("zone"); zone != "" {
+		q.Zone = zone
+	} else {
+		lat, lon, err := parseLatLon(c)
+		if err != nil {
+			return errorResponse(c, err)
+		}
+		q.Lat, q.Lon = lat, lon
+	}
 
-	res, err := h.svc.GetTodayForecast(c.Request().Context(), lat, lon)
+	alerts, err := h.svc.ListAlerts(c.Request().Context(), q)
 	if err != nil {
-		c.Logger().Error(err)
-		return c.JSON(http.StatusBadGateway, ErrorResponse{
-			Message: err.Error(),
+		return errorResponse(c, err)
+	}
+
+	out := AlertsResponse{Alerts: make([]AlertResponse, 0, len(alerts))}
+	for _, a := range alerts {
+		out.Alerts = append(out.Alerts, AlertResponse{
+			ID:            a.ID,
+			Event:         a.Event,
+			Severity:      a.Severity,
+			Urgency:       a.Urgency,
+			Certainty:     a.Certainty,
+			Onset:         a.Onset,
+			Expires:       a.Expires,
+			Headline:      a.Headline,
+			Description:   a.Description,
+			Instruction:   a.Instruction,
+			AreaDesc:      a.AreaDesc,
+			AffectedZones: a.AffectedZones,
 		})
 	}
+	return c.JSON(http.StatusOK, out)
+}
 
-	return c.JSON(http.StatusOK, ForecastResponse{
-		ShortForecast: res.ShortForecast,
-		TemperatureF:  res.TemperatureF,
-		Category:      res.Category,
+// GetCurrentConditions godoc
+// @Summary Get current conditions
+// @Description Returns the latest observation from the NWS station nearest to the point and its temperature category
+// @Param lat query number true "Latitude"
+// @Param lon query number true "Longitude"
+// @Param profile query string false "Category profile, see /categories"
+// @Param units query string false "Unit system: us (default), si or metric"
+// @Param Accept-Language header string false "Language of texts and messages: en (default) or es"
+// @Produce json
+// @Success 200 {object} ConditionsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Failure 504 {object} ErrorResponse
+// @Router /conditions [get]
+func (h *WeatherHandler) GetCurrentConditions(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	lat, lon, err := parseLatLon(c)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	sys, err := domain.ParseUnitSystem(c.QueryParam("units"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	cc, err := h.svc.GetCurrentConditions(c.Request().Context(), lat, lon, c.QueryParam("profile"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+	l := localizer(c)
+
+	return c.JSON(http.StatusOK, ConditionsResponse{
+		StationID:         cc.StationID,
+		StationName:       cc.StationName,
+		StationDistanceKm: cc.StationDistance.In(domain.Kilometers).Value,
+		ObservedAt:        cc.ObservedAt,
+		TextDescription:   l.Forecast(cc.TextDescription),
+		TemperatureF:      domain.ValueIn(cc.Temperature, domain.Fahrenheit),
+		FeelsLikeF:        domain.ValueIn(cc.FeelsLike, domain.Fahrenheit),
+		DewpointF:         domain.ValueIn(cc.Dewpoint, domain.Fahrenheit),
+		RelativeHumidity:  cc.RelativeHumidity,
+		WindSpeedMph:      domain.ValueIn(cc.WindSpeed, domain.MilesPerHour),
+		WindDirectionDeg:  cc.WindDirectionDeg,
+		PressureMb:        domain.ValueIn(cc.Pressure, domain.Millibars),
+		VisibilityMiles:   domain.ValueIn(cc.Visibility, domain.Miles),
+		Category:          cc.Category,
+		CategoryText:      l.Category(cc.Category),
+		StationDistance:   quantity(sys, &cc.StationDistance),
+		Temperature:       quantity(sys, cc.Temperature),
+		FeelsLike:         quantity(sys, cc.FeelsLike),
+		Dewpoint:          quantity(sys, cc.Dewpoint),
+		WindSpeed:         quantity(sys, cc.WindSpeed),
+		Pressure:          quantity(sys, cc.Pressure),
+		Visibility:        quantity(sys, cc.Visibility),
 	})
 }
 
+// ListCategories godoc
+// @Summary List temperature category profiles
+// @Description Returns the profiles selectable with the profile parameter and the °F band of each of their categories
+// @Param Accept-Language header string false "Language of category texts: en (default) or es"
+// @Produce json
+// @Success 200 {array} CategoryProfileResponse
+// @Router /categories [get]
+func (h *WeatherHandler) ListCategories(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	l := localizer(c)
+	profiles := h.svc.CategoryProfiles()
+	out := make([]CategoryProfileResponse, 0, len(profiles))
+	for _, p := range profiles {
+		res := CategoryProfileResponse{Name: p.Name, Default: p.Name == domain.DefaultCategoryProfile, FeelsLike: p.FeelsLike}
+		for i, label := range p.Labels {
+			band := CategoryResponse{Label: label, Text: l.Category(label)}
+			if i > 0 {
+				band.MinF = &p.Thresholds[i-1]
+			}
+			if i < len(p.Thresholds) {
+				band.MaxF = &p.Thresholds[i]
+			}
+			res.Categories = append(res.Categories, band)
+		}
+		out = append(out, res)
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
+// errorResponse reports invalid fields with 400 and failed calls to the
+// forecast provider with the status matching their cause, in the language
+// of the request. Only validation errors carry the untranslated message in
+// detail; the cause of a failed call is logged, not sent to clients.
+func errorResponse(c echo.Context, err error) error {
+	l := localizer(c)
+	var verr *domain.ValidationError
+	if errors.As(err, &verr) {
+		res := ErrorResponse{Message: l.Error(err), Detail: verr.Error()}
+		for _, v := range verr.Violations {
+			res.Fields = append(res.Fields, FieldErrorResponse{Field: v.Field, Description: l.Violation(v)})
+		}
+		return c.JSON(http.StatusBadRequest, res)
+	}
+	c.Logger().Error(err)
+	return c.JSON(upstreamStatus(err), ErrorResponse{Message: l.Error(err)})
+}
+
+const localizerKey = "i18n.localizer"
+
+// localizer picks the language of the response from Accept-Language, once
+// per request, and reports it in Content-Language.
+func localizer(c echo.Context) *i18n.Localizer {
+	if l, ok := c.Get(localizerKey).(*i18n.Localizer); ok {
+		return l
+	}
+	l := i18n.Match(c.Request().Header.Get("Accept-Language"))
+	c.Response().Header().Set("Content-Language", l.Locale())
+	c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+	c.Set(localizerKey, l)
+	return l
+}
+
+func invalidField(field, format string, args ...any) error {
+	return &domain.ValidationError{Violations: []domain.FieldViolation{domain.Violation(field, format, args...)}}
+}
+
+// upstreamStatus is the status of a failed call to the provider; the gRPC
+// adapter maps the same causes to the matching codes.
+func upstreamStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, domain.ErrUpstreamUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
+
+// quantity converts an optional quantity to the unit system of the request,
+// rounded to hundredths so conversions do not leak float noise.
+func quantity(sys domain.UnitSystem, q *domain.Quantity) *QuantityResponse {
+	if q == nil {
+		return nil
+	}
+	c := sys.Convert(*q)
+	return &QuantityResponse{Value: math.Round(c.Value*100) / 100, Unit: string(c.Unit)}
+}
+
+// parseLatLon reads the point from the query; the service checks coverage.
+func parseLatLon(c echo.Context) (float64, float64, error) {
+	return domain.ParseLatLon(c.QueryParam("lat"), c.QueryParam("lon"), nil)
+}
+
+// QuantityResponse is a measurement in the unit system of the request, with
+// the symbol of its unit, e.g. "C", "km/h" or "hPa".
+type QuantityResponse struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// ForecastResponse carries the temperatures in the requested units and,
+// for compatibility, always in °F. Category is the label of the profile,
+// CategoryText its translation.
 type ForecastResponse struct {
-	ShortForecast string  `json:"shortForecast"`
-	TemperatureF  float64 `json:"temperatureF"`
-	Category      string  `json:"category"`
+	ShortForecast string            `json:"shortForecast"`
+	Temperature   *QuantityResponse `json:"temperature"`
+	FeelsLike     *QuantityResponse `json:"feelsLike"`
+	High          *QuantityResponse `json:"high,omitempty"`
+	Low           *QuantityResponse `json:"low,omitempty"`
+	TemperatureF  float64           `json:"temperatureF"`
+	FeelsLikeF    float64           `json:"feelsLikeF"`
+	HighF         *float64          `json:"highF,omitempty"`
+	LowF          *float64          `json:"lowF,omitempty"`
+	Category      string            `json:"category"`
+	CategoryText  string            `json:"categoryText"`
+	Summary       string            `json:"summary"`
+	Stale         bool              `json:"stale,omitempty"`
+}
+
+type CategoryProfileResponse struct {
+	Name       string             `json:"name"`
+	Default    bool               `json:"default,omitempty"`
+	FeelsLike  bool               `json:"feelsLike,omitempty"`
+	Categories []CategoryResponse `json:"categories"`
+}
+
+// CategoryResponse is the band [minF, maxF) of a category; the coldest has
+// no minF and the hottest no maxF.
+type CategoryResponse struct {
+	Label string   `json:"label"`
+	Text  string   `json:"text"`
+	MinF  *float64 `json:"minF,omitempty"`
+	MaxF  *float64 `json:"maxF,omitempty"`
 }
 
 type ErrorResponse struct {
-	Message string `json:"message"`
+	Message string               `json:"message"`
+	Detail  string               `json:"detail,omitempty"`
+	Fields  []FieldErrorResponse `json:"fields,omitempty"`
+}
+
+type FieldErrorResponse struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+type ForecastPeriodResponse struct {
+	Name             string            `json:"name"`
+	StartTime        time.Time         `json:"startTime"`
+	EndTime          time.Time         `json:"endTime"`
+	IsDaytime        bool              `json:"isDaytime"`
+	Temperature      *QuantityResponse `json:"temperature"`
+	TemperatureF     float64           `json:"temperatureF"`
+	ShortForecast    string            `json:"shortForecast"`
+	DetailedForecast string            `json:"detailedForecast"`
+}
+
+type DailyForecastResponse struct {
+	Periods []ForecastPeriodResponse `json:"periods"`
+}
+
+type HourlyPeriodResponse struct {
+	StartTime                time.Time         `json:"startTime"`
+	EndTime                  time.Time         `json:"endTime"`
+	Temperature              *QuantityResponse `json:"temperature"`
+	WindSpeed                *QuantityResponse `json:"windSpeed"`
+	TemperatureF             float64           `json:"temperatureF"`
+	WindSpeedMph             float64           `json:"windSpeedMph"`
+	WindDirection            string            `json:"windDirection"`
+	PrecipitationProbability float64           `json:"precipitationProbability"`
+	ShortForecast            string            `json:"shortForecast"`
+}
+
+type HourlyForecastResponse struct {
+	Hours []HourlyPeriodResponse `json:"hours"`
+}
+
+type AlertResponse struct {
+	ID            string    `json:"id"`
+	Event         string    `json:"event"`
+	Severity      string    `json:"severity"`
+	Urgency       string    `json:"urgency"`
+	Certainty     string    `json:"certainty"`
+	Onset         time.Time `json:"onset"`
+	Expires       time.Time `json:"expires"`
+	Headline      string    `json:"headline"`
+	Description   string    `json:"description"`
+	Instruction   string    `json:"instruction,omitempty"`
+	AreaDesc      string    `json:"areaDesc"`
+	AffectedZones []string  `json:"affectedZones"`
+}
+
+type AlertsResponse struct {
+	Alerts []AlertResponse `json:"alerts"`
+}
+
+// ConditionsResponse carries the measurements in the requested units and,
+// for compatibility, in the fixed units of the suffixed fields.
+type ConditionsResponse struct {
+	StationID         string            `json:"stationId"`
+	StationName       string            `json:"stationName"`
+	StationDistanceKm float64           `json:"stationDistanceKm"`
+	ObservedAt        time.Time         `json:"observedAt"`
+	TextDescription   string            `json:"textDescription"`
+	TemperatureF      *float64          `json:"temperatureF"`
+	FeelsLikeF        *float64          `json:"feelsLikeF"`
+	DewpointF         *float64          `json:"dewpointF"`
+	RelativeHumidity  *float64          `json:"relativeHumidity"`
+	WindSpeedMph      *float64          `json:"windSpeedMph"`
+	WindDirectionDeg  *float64          `json:"windDirectionDeg"`
+	PressureMb        *float64          `json:"pressureMb"`
+	VisibilityMiles   *float64          `json:"visibilityMiles"`
+	Category          string            `json:"category,omitempty"`
+	CategoryText      string            `json:"categoryText,omitempty"`
+	StationDistance   *QuantityResponse `json:"stationDistance"`
+	Temperature       *QuantityResponse `json:"temperature"`
+	FeelsLike         *QuantityResponse `json:"feelsLike"`
+	Dewpoint          *QuantityResponse `json:"dewpoint"`
+	WindSpeed         *QuantityResponse `json:"windSpeed"`
+	Pressure          *QuantityResponse `json:"pressure"`
+	Visibility        *QuantityResponse `json:"visibility"`
 }
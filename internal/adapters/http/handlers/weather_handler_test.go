@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	echo "github.com/labstack/echo/v4"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+)
+
+// fakeSvc answers every call with today, hours or err.
+type fakeSvc struct {
+	today domain.TodayForecast
+	hours []domain.HourlyPeriod
+	err   error
+}
+
+func (s fakeSvc) GetTodayForecast(ctx context.Context, lat, lon float64, profile string) (domain.TodayForecast, error) {
+	return s.today, s.err
+}
+
+func (s fakeSvc) GetForecast(ctx context.Context, lat, lon float64, days int) ([]domain.ForecastPeriod, error) {
+	return nil, s.err
+}
+
+func (s fakeSvc) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) ([]domain.HourlyPeriod, error) {
+	return s.hours, s.err
+}
+
+func (s fakeSvc) ListAlerts(ctx context.Context, q domain.AlertQuery) ([]domain.Alert, error) {
+	return nil, s.err
+}
+
+func (s fakeSvc) GetCurrentConditions(ctx context.Context, lat, lon float64, profile string) (domain.CurrentConditions, error) {
+	return domain.CurrentConditions{}, s.err
+}
+
+func (s fakeSvc) CategoryProfiles() []domain.CategoryProfile { return nil }
+
+var sunny = domain.TodayForecast{
+	ShortForecast: "Sunny",
+	Temperature:   domain.Quantity{Value: 77, Unit: domain.Fahrenheit},
+	FeelsLike:     domain.Quantity{Value: 77, Unit: domain.Fahrenheit},
+	Category:      "moderate",
+}
+
+// get serves target with the routes of the weather API.
+func get(t *testing.T, svc fakeSvc, target string, header ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	h := NewWeatherHandler(svc)
+	e := echo.New()
+	e.GET("/forecast", h.GetTodayForecast)
+	e.GET("/forecast/hourly", h.GetHourlyForecast)
+	e.GET("/alerts", h.ListAlerts)
+	e.GET("/conditions", h.GetCurrentConditions)
+	e.GET("/categories", h.ListCategories)
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body, err)
+	}
+	return v
+}
+
+func TestGetTodayForecast(t *testing.T) {
+	rec := get(t, fakeSvc{today: sunny}, "/forecast?lat=39.74&lon=-104.99")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get(echo.HeaderContentType); got != echo.MIMEApplicationJSONCharsetUTF8 {
+		t.Errorf("content type = %q", got)
+	}
+	if rec.Header().Get("X-Cache") != "" || rec.Header().Get("Warning") != "" {
+		t.Errorf("fresh forecast has stale headers: %v", rec.Header())
+	}
+	res := decode[ForecastResponse](t, rec)
+	if res.ShortForecast != "Sunny" || res.TemperatureF != 77 || *res.Temperature != (QuantityResponse{77, "F"}) || res.Stale {
+		t.Errorf("response = %+v", res)
+	}
+	if res.Summary != "Sunny. 77°F (moderate)." {
+		t.Errorf("summary = %q", res.Summary)
+	}
+}
+
+func TestGetTodayForecast_Stale(t *testing.T) {
+	stale := sunny
+	stale.Stale = true
+	rec := get(t, fakeSvc{today: stale}, "/forecast?lat=39.74&lon=-104.99")
+	if rec.Header().Get("X-Cache") != "STALE" || rec.Header().Get("Warning") != `110 - "Response is Stale"` {
+		t.Errorf("headers = %v", rec.Header())
+	}
+	if !decode[ForecastResponse](t, rec).Stale {
+		t.Error("response not marked stale")
+	}
+}
+
+func TestUnits(t *testing.T) {
+	rec := get(t, fakeSvc{today: sunny}, "/forecast?lat=39.74&lon=-104.99&units=METRIC")
+	res := decode[ForecastResponse](t, rec)
+	if *res.Temperature != (QuantityResponse{25, "C"}) || res.TemperatureF != 77 {
+		t.Errorf("metric temperature = %+v, %v°F", res.Temperature, res.TemperatureF)
+	}
+
+	hours := []domain.HourlyPeriod{{
+		StartTime:   time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC),
+		Temperature: domain.Quantity{Value: 50, Unit: domain.Fahrenheit},
+		WindSpeed:   domain.Quantity{Value: 10, Unit: domain.MilesPerHour},
+	}}
+	for units, want := range map[string]QuantityResponse{"": {10, "mph"}, "si": {4.47, "m/s"}, "metric": {16.09, "km/h"}} {
+		rec := get(t, fakeSvc{hours: hours}, "/forecast/hourly?lat=39.74&lon=-104.99&units="+units)
+		res := decode[HourlyForecastResponse](t, rec)
+		if len(res.Hours) != 1 || *res.Hours[0].WindSpeed != want || res.Hours[0].WindSpeedMph != 10 {
+			t.Errorf("units %q: hours = %+v", units, res.Hours)
+		}
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	rec := get(t, fakeSvc{today: sunny}, "/forecast?lat=39.74&lon=-104.99", "Accept-Language", "es-MX, en;q=0.5")
+	if got := rec.Header().Get("Content-Language"); got != "es" {
+		t.Errorf("content language = %q", got)
+	}
+	if got := rec.Header().Get(echo.HeaderVary); !strings.Contains(got, "Accept-Language") {
+		t.Errorf("vary = %q", got)
+	}
+	res := decode[ForecastResponse](t, rec)
+	if res.ShortForecast != "Soleado" || res.Category != "moderate" || res.CategoryText != "moderado" {
+		t.Errorf("response = %+v", res)
+	}
+
+	rec = get(t, fakeSvc{today: sunny}, "/forecast?lat=39.74&lon=-104.99", "Accept-Language", "fr")
+	if got := rec.Header().Get("Content-Language"); got != "en" {
+		t.Errorf("content language for fr = %q, want the default", got)
+	}
+}
+
+func TestInvalidRequest(t *testing.T) {
+	cases := []struct {
+		target, lang string
+		message      string
+		fields       []FieldErrorResponse
+	}{
+		{"/forecast?lon=abc", "", "invalid lat is required, lon must be a decimal number",
+			[]FieldErrorResponse{{"lat", "is required"}, {"lon", "must be a decimal number"}}},
+		{"/forecast?lon=abc", "es", "parámetros no válidos: lat es obligatorio, lon debe ser un número decimal",
+			[]FieldErrorResponse{{"lat", "es obligatorio"}, {"lon", "debe ser un número decimal"}}},
+		{"/conditions?lat=1&lon=2&units=kelvin", "", "invalid units must be one of us, si or metric",
+			[]FieldErrorResponse{{"units", "must be one of us, si or metric"}}},
+		{"/forecast/hourly?lat=1&lon=2&hours=0", "", "invalid hours must be between 1 and 156",
+			[]FieldErrorResponse{{"hours", "must be between 1 and 156"}}},
+	}
+	for _, tc := range cases {
+		rec := get(t, fakeSvc{today: sunny}, tc.target, "Accept-Language", tc.lang)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d", tc.target, rec.Code)
+			continue
+		}
+		res := decode[ErrorResponse](t, rec)
+		if res.Message != tc.message || res.Detail == "" || fmt.Sprint(res.Fields) != fmt.Sprint(tc.fields) {
+			t.Errorf("%s (%s): response = %+v", tc.target, tc.lang, res)
+		}
+	}
+}
+
+func TestUpstreamErrors(t *testing.T) {
+	cases := []struct {
+		err     error
+		status  int
+		message string
+	}{
+		{fmt.Errorf("points: %w", domain.ErrNotFound), http.StatusNotFound, "No forecast is available for this location."},
+		{fmt.Errorf("nws: %w", domain.ErrRateLimited), http.StatusTooManyRequests, "The weather provider is busy, try again shortly."},
+		{fmt.Errorf("nws: %w", domain.ErrUpstreamUnavailable), http.StatusServiceUnavailable, "The weather provider is unavailable, try again later."},
+		{fmt.Errorf("nws: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, "The weather provider is unavailable, try again later."},
+		{fmt.Errorf("nws: %w", domain.ErrBadUpstreamData), http.StatusBadGateway, "The weather provider sent an unexpected response."},
+		{errors.New("boom"), http.StatusBadGateway, "The weather provider sent an unexpected response."},
+	}
+	for _, tc := range cases {
+		for _, target := range []string{"/forecast?lat=1&lon=2", "/alerts?zone=MDZ011", "/conditions?lat=1&lon=2"} {
+			rec := get(t, fakeSvc{err: tc.err}, target)
+			if rec.Code != tc.status {
+				t.Errorf("%s with %v: status = %d, want %d", target, tc.err, rec.Code, tc.status)
+			}
+			res := decode[ErrorResponse](t, rec)
+			if res.Message != tc.message || res.Detail != "" || res.Fields != nil {
+				t.Errorf("%s with %v: response = %+v", target, tc.err, res)
+			}
+			if strings.Contains(rec.Body.String(), "nws") || strings.Contains(rec.Body.String(), "boom") {
+				t.Errorf("%s with %v: body leaks the cause: %s", target, tc.err, rec.Body)
+			}
+		}
+	}
+}
+
+func TestListCategories(t *testing.T) {
+	rec := get(t, fakeSvc{}, "/categories")
+	if got := rec.Header().Get(echo.HeaderContentType); got != echo.MIMEApplicationJSONCharsetUTF8 {
+		t.Errorf("content type = %q", got)
+	}
+}
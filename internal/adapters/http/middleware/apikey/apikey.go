@@ -1,6 +1,7 @@
 package apikey
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"os"
 
@@ -22,3 +23,20 @@ func OptionalCheckerFromEnv() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// RequiredCheckerFromEnv ask for X-Admin-Key matching the env var; if the env
+// var is empty every request is rejected.
+func RequiredCheckerFromEnv(env string) echo.MiddlewareFunc {
+	want := os.Getenv(env)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if want == "" {
+				return c.JSON(http.StatusForbidden, map[string]string{"message": "admin API disabled"})
+			}
+			if subtle.ConstantTimeCompare([]byte(c.Request().Header.Get("X-Admin-Key")), []byte(want)) != 1 {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"message": "missing or invalid admin key"})
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Package i18n translates what the HTTP and gRPC adapters show to users:
+// category labels, short forecasts, error messages and forecast summaries.
+//
+// Catalogs are keyed by the English text, printf formats included, so a
+// missing translation falls back to English and then to the key itself.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+)
+
+// Default is the locale used when a request asks for none we have.
+const Default = "en"
+
+//go:embed locales/*.json
+var files embed.FS
+
+type catalog struct {
+	Messages   map[string]string `json:"messages"`
+	Categories map[string]string `json:"categories"`
+	// Forecasts translates NWS short forecasts, matched ignoring case.
+	Forecasts map[string]string `json:"forecasts"`
+	Summary   string            `json:"summary"`
+
+	summary *template.Template
+}
+
+var catalogs = mustLoad()
+
+func mustLoad() map[string]*catalog {
+	entries, err := files.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	out := make(map[string]*catalog, len(entries))
+	for _, e := range entries {
+		tag := strings.TrimSuffix(e.Name(), ".json")
+		b, err := files.ReadFile(path.Join("locales", e.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var c catalog
+		if err := json.Unmarshal(b, &c); err != nil {
+			panic(fmt.Sprintf("i18n: %s: %v", e.Name(), err))
+		}
+		forecasts := make(map[string]string, len(c.Forecasts))
+		for k, v := range c.Forecasts {
+			forecasts[strings.ToLower(k)] = v
+		}
+		c.Forecasts = forecasts
+		if c.Summary != "" {
+			c.summary = template.Must(template.New(tag).Option("missingkey=error").Parse(c.Summary))
+		}
+		out[tag] = &c
+	}
+	if out[Default] == nil || out[Default].summary == nil {
+		panic("i18n: no summary in the " + Default + " catalog")
+	}
+	return out
+}
+
+// Supported lists the locales there are catalogs for.
+func Supported() []string {
+	out := make([]string, 0, len(catalogs))
+	for tag := range catalogs {
+		out = append(out, tag)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Localizer translates into one locale, falling back to Default.
+type Localizer struct {
+	locale   string
+	cat, def *catalog
+}
+
+// Match picks the locale for an Accept-Language header, which may also be a
+// single tag such as "es-MX": the preferred language we have a catalog for,
+// matching a regional tag by its language, or Default.
+func Match(accept string) *Localizer {
+	type pref struct {
+		tag string
+		q   float64
+	}
+	var prefs []pref
+	for _, part := range strings.Split(accept, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil || math.IsNaN(q) {
+				continue
+			}
+		}
+		if tag != "" && q > 0 {
+			prefs = append(prefs, pref{tag, q})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+
+	for _, p := range prefs {
+		if p.tag == "*" {
+			break
+		}
+		lang, _, _ := strings.Cut(p.tag, "-")
+		for _, tag := range []string{p.tag, lang} {
+			if c, ok := catalogs[tag]; ok {
+				return &Localizer{locale: tag, cat: c, def: catalogs[Default]}
+			}
+		}
+	}
+	return &Localizer{locale: Default, cat: catalogs[Default], def: catalogs[Default]}
+}
+
+// Locale is the tag of the catalog in use, e.g. "es".
+func (l *Localizer) Locale() string { return l.locale }
+
+func (l *Localizer) lookup(pick func(*catalog) map[string]string, key string) string {
+	if s, ok := pick(l.cat)[key]; ok {
+		return s
+	}
+	if s, ok := pick(l.def)[key]; ok {
+		return s
+	}
+	return key
+}
+
+func messages(c *catalog) map[string]string   { return c.Messages }
+func categories(c *catalog) map[string]string { return c.Categories }
+
+// Sprintf formats the translation of format.
+func (l *Localizer) Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(l.lookup(messages, format), args...)
+}
+
+// Category translates a category label; labels of custom profiles that no
+// catalog knows are returned as they are.
+func (l *Localizer) Category(label string) string {
+	if label == "" {
+		return ""
+	}
+	return l.lookup(categories, label)
+}
+
+// Forecast translates an NWS short forecast such as "Chance Rain Showers
+// then Mostly Sunny" phrase by phrase. Text with a phrase the catalog does
+// not know is returned untranslated rather than half translated.
+func (l *Localizer) Forecast(short string) string {
+	if short == "" || l.cat == l.def {
+		return short
+	}
+	phrases := strings.Split(short, " then ")
+	out := ""
+	for i, p := range phrases {
+		t, ok := l.cat.Forecasts[strings.ToLower(strings.TrimSpace(p))]
+		if !ok {
+			return short
+		}
+		if i == 0 {
+			out = t
+		} else {
+			out = l.Sprintf("%s then %s", out, t)
+		}
+	}
+	return out
+}
+
+// Violation translates why a field is invalid.
+func (l *Localizer) Violation(v domain.FieldViolation) string {
+	if v.Format == "" {
+		return v.Description
+	}
+	return l.Sprintf(v.Format, v.Args...)
+}
+
+// Error returns the message to show users for a failed request: what is
+// wrong with the request, or why the forecast could not be had.
+func (l *Localizer) Error(err error) string {
+	var verr *domain.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		parts := make([]string, 0, len(verr.Violations))
+		for _, v := range verr.Violations {
+			parts = append(parts, v.Field+" "+l.Violation(v))
+		}
+		return l.Sprintf("invalid %s", strings.Join(parts, ", "))
+	case errors.Is(err, domain.ErrNotFound):
+		return l.Sprintf("No forecast is available for this location.")
+	case errors.Is(err, domain.ErrRateLimited):
+		return l.Sprintf("The weather provider is busy, try again shortly.")
+	case errors.Is(err, domain.ErrUpstreamUnavailable), errors.Is(err, context.DeadlineExceeded):
+		return l.Sprintf("The weather provider is unavailable, try again later.")
+	}
+	return l.Sprintf("The weather provider sent an unexpected response.")
+}
+
+// Temperature formats a temperature in whole degrees of the unit system.
+func (l *Localizer) Temperature(sys domain.UnitSystem, q domain.Quantity) string {
+	c := sys.Convert(q)
+	return l.Sprintf("%d°%s", int(math.Round(c.Value)), c.Unit)
+}
+
+// summary is what summary templates can refer to; temperatures are
+// formatted and empty when unknown, FeelsLike also when it rounds to the
+// air temperature.
+type summary struct {
+	Forecast    string
+	Temperature string
+	FeelsLike   string
+	High        string
+	Low         string
+	Category    string
+}
+
+// Summary renders a one-line description of today's forecast.
+func (l *Localizer) Summary(f domain.TodayForecast, sys domain.UnitSystem) string {
+	s := summary{
+		Forecast:    l.Forecast(f.ShortForecast),
+		Temperature: l.Temperature(sys, f.Temperature),
+		Category:    l.Category(f.Category),
+	}
+	if fl := l.Temperature(sys, f.FeelsLike); fl != s.Temperature && f.FeelsLike.Unit != "" {
+		s.FeelsLike = fl
+	}
+	if f.High != nil {
+		s.High = l.Temperature(sys, *f.High)
+	}
+	if f.Low != nil {
+		s.Low = l.Temperature(sys, *f.Low)
+	}
+
+	tmpl := l.cat.summary
+	if tmpl == nil {
+		tmpl = l.def.summary
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, s); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+)
+
+func TestMatch(t *testing.T) {
+	cases := map[string]string{
+		"":                          "en",
+		"es":                        "es",
+		"es-MX":                     "es",
+		"ES_us":                     "es",
+		"fr-CA, es;q=0.8, en;q=0.5": "es",
+		"en;q=0.4, es;q=0.9":        "es",
+		"es;q=0, en":                "en",
+		"es;q=abc, en":              "en",
+		"fr, de":                    "en",
+		"*":                         "en",
+	}
+	for accept, want := range cases {
+		if got := Match(accept).Locale(); got != want {
+			t.Errorf("Match(%q) = %s, want %s", accept, got, want)
+		}
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	es, en := Match("es"), Match("en")
+
+	if got := es.Category("hot"); got != "caluroso" {
+		t.Errorf("category hot = %q", got)
+	}
+	if got := es.Category("balmy"); got != "balmy" {
+		t.Errorf("unknown category = %q, want it untranslated", got)
+	}
+	if got := es.Forecast("Chance Rain Showers then Mostly Sunny"); got != "Posibles chubascos, luego Mayormente soleado" {
+		t.Errorf("forecast = %q", got)
+	}
+	if got := es.Forecast("Sunny then Volcanic Ash"); got != "Sunny then Volcanic Ash" {
+		t.Errorf("partly known forecast = %q, want it untranslated", got)
+	}
+	if got := en.Forecast("Sunny"); got != "Sunny" {
+		t.Errorf("en forecast = %q", got)
+	}
+
+	_, _, err := domain.ParseLatLon("", "1e999", nil)
+	if got := en.Error(err); got != err.Error() {
+		t.Errorf("en error = %q, want %q", got, err.Error())
+	}
+	if got := es.Error(err); got != "parámetros no válidos: lat es obligatorio, lon debe ser un número decimal" {
+		t.Errorf("es error = %q", got)
+	}
+	if got := es.Error(fmt.Errorf("points: %w", domain.ErrNotFound)); got != "No hay pronóstico disponible para esta ubicación." {
+		t.Errorf("es not found = %q", got)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	high := domain.Quantity{Value: 91, Unit: domain.Fahrenheit}
+	f := domain.TodayForecast{
+		ShortForecast: "Mostly Sunny",
+		Temperature:   domain.Quantity{Value: 88, Unit: domain.Fahrenheit},
+		FeelsLike:     domain.Quantity{Value: 95.4, Unit: domain.Fahrenheit},
+		High:          &high,
+		Category:      "hot",
+	}
+	if got, want := Match("en").Summary(f, domain.US), "Mostly Sunny. 88°F, feels like 95°F (hot). High 91°F."; got != want {
+		t.Errorf("en summary = %q, want %q", got, want)
+	}
+	if got, want := Match("es").Summary(f, domain.Metric), "Mayormente soleado. 31 °C, sensación térmica de 35 °C (caluroso). Máxima de 33 °C."; got != want {
+		t.Errorf("es summary = %q, want %q", got, want)
+	}
+
+	f.FeelsLike, f.High = f.Temperature, nil
+	if got, want := Match("en").Summary(f, domain.US), "Mostly Sunny. 88°F (hot)."; got != want {
+		t.Errorf("summary without feels-like and high = %q, want %q", got, want)
+	}
+}
+
+// TestCatalogFormats keeps translators from dropping or adding printf verbs.
+func TestCatalogFormats(t *testing.T) {
+	verb := regexp.MustCompile(`%[a-z]`)
+	for tag, c := range catalogs {
+		for key, msg := range c.Messages {
+			if fmt.Sprint(verb.FindAllString(key, -1)) != fmt.Sprint(verb.FindAllString(msg, -1)) {
+				t.Errorf("%s: %q translates %q with other verbs", tag, msg, key)
+			}
+		}
+	}
+}
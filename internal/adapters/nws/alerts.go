@@ -0,0 +1,97 @@
+package nws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+	obs "github.com/rcglezreyes/go_weather/observability/metrics"
+)
+
+// GetActiveAlerts returns the watches, warnings and advisories currently in
+// effect for a forecast zone or, when no zone is given, for a point.
+func (c *Client) GetActiveAlerts(ctx context.Context, q domain.AlertQuery) ([]domain.Alert, error) {
+	start := time.Now()
+	defer func() {
+		obs.NWSRequestsTotal.Inc()
+		obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	params := url.Values{}
+	if q.Zone != "" {
+		params.Set("zone", q.Zone)
+	} else {
+		params.Set("point", fmt.Sprintf("%.4f,%.4f", q.Lat, q.Lon))
+	}
+	alertsURL := c.baseURL + "/alerts/active?" + params.Encode()
+
+	resp, err := c.doNWS(ctx, http.MethodGet, alertsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, problemFrom(resp, "alerts")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read alerts body: %w", err)
+	}
+
+	var ac alertsCollection
+	if err := json.Unmarshal(body, &ac); err != nil {
+		return nil, badData("alerts: %w", err)
+	}
+
+	// ld+json lists alerts in @graph, geo+json wraps them in features
+	props := ac.Graph
+	for _, f := range ac.Features {
+		props = append(props, f.Properties)
+	}
+
+	out := make([]domain.Alert, 0, len(props))
+	for _, a := range props {
+		out = append(out, toAlert(a))
+	}
+	return out, nil
+}
+
+func toAlert(a alertProps) domain.Alert {
+	zones := make([]string, 0, len(a.AffectedZones))
+	for _, z := range a.AffectedZones {
+		// zones come as URLs, e.g. https://api.weather.gov/zones/forecast/MDZ011
+		zones = append(zones, path.Base(z))
+	}
+	return domain.Alert{
+		ID:            a.ID,
+		Event:         a.Event,
+		Severity:      a.Severity,
+		Urgency:       a.Urgency,
+		Certainty:     a.Certainty,
+		Onset:         timeOrZero(a.Onset),
+		Expires:       timeOrZero(a.Expires),
+		Headline:      a.Headline,
+		Description:   a.Description,
+		Instruction:   a.Instruction,
+		AreaDesc:      a.AreaDesc,
+		AffectedZones: zones,
+	}
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,61 @@
+package nws
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// periodsDoc is a parsed forecast document with the validators and expiry
+// NWS sent along.
+type periodsDoc struct {
+	Periods      []period
+	ETag         string
+	LastModified string
+	// Expires is zero when NWS did not say how long the document is fresh.
+	Expires time.Time
+}
+
+func (d periodsDoc) conditionalHeader() http.Header {
+	if d.ETag == "" && d.LastModified == "" {
+		return nil
+	}
+	h := http.Header{}
+	if d.ETag != "" {
+		h.Set("If-None-Match", d.ETag)
+	}
+	if d.LastModified != "" {
+		h.Set("If-Modified-Since", d.LastModified)
+	}
+	return h
+}
+
+// expiresAt returns when a response stops being fresh according to its
+// Cache-Control max-age or, failing that, its Expires header; zero if it does
+// not say or must not be cached.
+func expiresAt(h http.Header, now time.Time) time.Time {
+	for _, dir := range strings.Split(h.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(dir), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache":
+			return time.Time{}
+		case "max-age", "s-maxage":
+			secs, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err != nil {
+				continue
+			}
+			age, _ := strconv.Atoi(h.Get("Age"))
+			return now.Add(time.Duration(secs-age) * time.Second)
+		}
+	}
+	exp, err := http.ParseTime(h.Get("Expires"))
+	if err != nil {
+		return time.Time{}
+	}
+	// measure against the server clock when it tells us the time
+	if date, err := http.ParseTime(h.Get("Date")); err == nil {
+		return now.Add(exp.Sub(date))
+	}
+	return exp
+}
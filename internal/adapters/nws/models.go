@@ -1,20 +1,67 @@
 package nws
 
+import (
+	"fmt"
+	"time"
+)
+
 // points: top-level fields
 type pointsResp struct {
-	Forecast       string `json:"forecast"`
-	ForecastHourly string `json:"forecastHourly"`
-	GridID         string `json:"gridId"`
-	GridX          int    `json:"gridX"`
-	GridY          int    `json:"gridY"`
+	Forecast            string `json:"forecast"`
+	ForecastHourly      string `json:"forecastHourly"`
+	ObservationStations string `json:"observationStations"`
+	GridID              string `json:"gridId"`
+	GridX               int    `json:"gridX"`
+	GridY               int    `json:"gridY"`
+	TimeZone            string `json:"timeZone"`
+}
+
+// forecastURL prefers the gridpoints endpoint derived from the grid mapping.
+func (p pointsResp) forecastURL(base string) string {
+	if p.GridID != "" {
+		return fmt.Sprintf("%s/gridpoints/%s/%d,%d/forecast", base, p.GridID, p.GridX, p.GridY)
+	}
+	return p.Forecast
+}
+
+// hourlyURL prefers the gridpoints endpoint derived from the grid mapping.
+func (p pointsResp) hourlyURL(base string) string {
+	if p.GridID != "" {
+		return fmt.Sprintf("%s/gridpoints/%s/%d,%d/forecast/hourly", base, p.GridID, p.GridX, p.GridY)
+	}
+	return p.ForecastHourly
+}
+
+// stationsURL prefers the gridpoints endpoint derived from the grid mapping.
+func (p pointsResp) stationsURL(base string) string {
+	if p.GridID != "" {
+		return fmt.Sprintf("%s/gridpoints/%s/%d,%d/stations", base, p.GridID, p.GridX, p.GridY)
+	}
+	return p.ObservationStations
 }
 
 // periods: forecast period info
 type forecastPeriod struct {
-	Name            string  `json:"name"`
-	Temperature     float64 `json:"temperature"`
-	TemperatureUnit string  `json:"temperatureUnit"`
-	ShortForecast   string  `json:"shortForecast"`
+	Name             string    `json:"name"`
+	StartTime        time.Time `json:"startTime"`
+	EndTime          time.Time `json:"endTime"`
+	IsDaytime        bool      `json:"isDaytime"`
+	Temperature      float64   `json:"temperature"`
+	TemperatureUnit  string    `json:"temperatureUnit"`
+	ShortForecast    string    `json:"shortForecast"`
+	DetailedForecast string    `json:"detailedForecast"`
+
+	// hourly forecast only
+	ProbabilityOfPrecipitation quantValue `json:"probabilityOfPrecipitation"`
+	RelativeHumidity           quantValue `json:"relativeHumidity"`
+	WindSpeed                  string     `json:"windSpeed"`
+	WindDirection              string     `json:"windDirection"`
+}
+
+// quantValue: NWS quantitative value, value is null when unavailable
+type quantValue struct {
+	UnitCode string   `json:"unitCode"`
+	Value    *float64 `json:"value"`
 }
 
 // forecastTop: top-level fields for forecast response
@@ -26,3 +73,64 @@ type forecastTop struct {
 type forecastWithProps struct {
 	Properties forecastTop `json:"properties"`
 }
+
+// alertProps: properties of an active alert
+type alertProps struct {
+	ID            string     `json:"id"`
+	Event         string     `json:"event"`
+	Severity      string     `json:"severity"`
+	Urgency       string     `json:"urgency"`
+	Certainty     string     `json:"certainty"`
+	Onset         *time.Time `json:"onset"`
+	Expires       *time.Time `json:"expires"`
+	Headline      string     `json:"headline"`
+	Description   string     `json:"description"`
+	Instruction   string     `json:"instruction"`
+	AreaDesc      string     `json:"areaDesc"`
+	AffectedZones []string   `json:"affectedZones"`
+}
+
+// alertsCollection: active alerts response, either ld+json (@graph) or geo+json (features)
+type alertsCollection struct {
+	Graph    []alertProps `json:"@graph"`
+	Features []struct {
+		Properties alertProps `json:"properties"`
+	} `json:"features"`
+}
+
+// station: observation station, ld+json carries geometry as WKT ("POINT(lon lat)")
+type station struct {
+	StationIdentifier string `json:"stationIdentifier"`
+	Name              string `json:"name"`
+	Geometry          string `json:"geometry"`
+}
+
+// stationsCollection: observation stations response, either ld+json (@graph) or geo+json (features)
+type stationsCollection struct {
+	Graph    []station `json:"@graph"`
+	Features []struct {
+		Geometry struct {
+			Coordinates []float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties station `json:"properties"`
+	} `json:"features"`
+}
+
+// observationProps: latest station observation, values in WMO units
+type observationProps struct {
+	Timestamp          time.Time  `json:"timestamp"`
+	TextDescription    string     `json:"textDescription"`
+	Temperature        quantValue `json:"temperature"`
+	Dewpoint           quantValue `json:"dewpoint"`
+	RelativeHumidity   quantValue `json:"relativeHumidity"`
+	WindSpeed          quantValue `json:"windSpeed"`
+	WindDirection      quantValue `json:"windDirection"`
+	BarometricPressure quantValue `json:"barometricPressure"`
+	Visibility         quantValue `json:"visibility"`
+}
+
+// observationResp: ld+json puts the observation at the top level, geo+json under properties
+type observationResp struct {
+	observationProps
+	Properties *observationProps `json:"properties"`
+}
@@ -7,190 +7,479 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+	"github.com/rcglezreyes/go_weather/internal/pkg/breaker"
+	"github.com/rcglezreyes/go_weather/internal/pkg/cache"
 	"github.com/rcglezreyes/go_weather/internal/pkg/httpclient"
 	obs "github.com/rcglezreyes/go_weather/observability/metrics"
 )
 
-type Client struct{ http *http.Client }
+// DefaultBaseURL is the public NWS API.
+const DefaultBaseURL = "https://api.weather.gov"
 
-func NewNWSClient() *Client {
-	return &Client{
-		http: httpclient.New("go_weather/1.0 (contact: rcglezreyes@gmail.com)"),
+// UserAgent identifies the service to NWS, which requires a contact.
+const UserAgent = "go_weather/1.0 (contact: rcglezreyes@gmail.com)"
+
+type Client struct {
+	http    *http.Client
+	baseURL string
+	points  *cache.Typed[pointsResp]
+	periods *cache.Typed[periodsDoc]
+	retry   RetryPolicy
+	breaker *breaker.Breaker
+	now     func() time.Time
+}
+
+type Option func(*Client)
+
+// WithBaseURL sends requests to a mirror or a fake of the NWS API instead of
+// DefaultBaseURL.
+func WithBaseURL(u string) Option {
+	return func(cl *Client) { cl.baseURL = strings.TrimRight(u, "/") }
+}
+
+// WithHTTPClient replaces the default httpclient.New(UserAgent) client, e.g.
+// to record or replay the NWS traffic.
+func WithHTTPClient(h *http.Client) Option {
+	return func(cl *Client) { cl.http = h }
+}
+
+// WithPointsCache keeps the /points grid resolution of each location in c.
+// The mapping practically never changes, so c should use a TTL of days.
+func WithPointsCache(c cache.KV) Option {
+	return func(cl *Client) { cl.points = cache.NewTyped[pointsResp](c, "points:") }
+}
+
+// WithBreaker fails calls fast with domain.ErrUpstreamUnavailable while b is
+// open. Connection errors, timeouts, 429 and 5xx responses count as failures.
+func WithBreaker(b *breaker.Breaker) Option {
+	return func(cl *Client) { cl.breaker = b }
+}
+
+// WithValidatorCache keeps the last forecast documents with their ETag and
+// Last-Modified validators in c, so that refreshing them is a conditional
+// request. Entries should outlive the forecast cache by far.
+func WithValidatorCache(c cache.KV) Option {
+	return func(cl *Client) { cl.periods = cache.NewTyped[periodsDoc](c, "periods:") }
+}
+
+// RegisterCacheTypes registers the values the client caches so that
+// serializing caches and snapshots can decode them. Names are versioned when
+// the shape of a value changes, so entries written before are dropped.
+func RegisterCacheTypes(c *cache.JSONCodec) {
+	c.Register("points.v2", pointsResp{})
+	c.Register("periods.v2", periodsDoc{})
+}
+
+func NewNWSClient(opts ...Option) *Client {
+	c := &Client{
+		http:    httpclient.New(UserAgent),
+		baseURL: DefaultBaseURL,
+		points:  cache.NewTyped[pointsResp](nil, "points:"),
+		periods: cache.NewTyped[periodsDoc](nil, "periods:"),
+		retry:   DefaultRetryPolicy,
+		now:     time.Now,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-func (c *Client) GetToday(ctx context.Context, lat, lon float64) (string, float64, error) {
+// GetToday returns the short forecast and temperature of the current
+// period, from the daily forecast or else the hourly one, with today's high
+// and tonight's low in the point's time zone. The feels-like temperature
+// takes the humidity of the current hour when the daily period has none.
+func (c *Client) GetToday(ctx context.Context, lat, lon float64) (domain.TodayForecast, error) {
 	start := time.Now()
 	defer func() { obs.NWSRequestsTotal.Inc() }()
 
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	pointsURL := fmt.Sprintf("https://api.weather.gov/points/%f,%f", lat, lon)
-	resp, err := c.doNWS(ctx, http.MethodGet, pointsURL)
-	if err != nil {
-		obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
-		return "", 0, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 300 {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
-		obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
-		return "", 0, fmt.Errorf("points status: %d body: %s", resp.StatusCode, string(b))
-	}
-
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20)) // 1MB
+	p, err := c.resolvePoints(ctx, lat, lon)
 	if err != nil {
 		obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
-		return "", 0, fmt.Errorf("read points body: %w", err)
-	}
-
-	var p pointsResp
-	if err := json.Unmarshal(body, &p); err != nil {
-		obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
-		return "", 0, fmt.Errorf("unmarshal points: %w; body=%s", err, string(body))
-	}
-
-	if p.Forecast == "" && p.ForecastHourly == "" {
-		obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
-		return "", 0, errors.New("no forecast URLs from points endpoint (after fallback)")
+		return domain.TodayForecast{}, err
 	}
 
-	var rForecast, rHourly []period
+	var rForecast, rHourly periodsDoc
 	var errForecast, errHourly error
 
 	g, ctx2 := errgroup.WithContext(ctx)
 
-	if p.Forecast != "" {
-		url := p.Forecast
+	if u := p.forecastURL(c.baseURL); u != "" {
+		url := u
 		g.Go(func() error {
-			pr, err := c.fetchPeriods(ctx2, url)
-			if err == nil {
-				rForecast = pr
-			} else {
-				errForecast = err
-			}
+			rForecast, errForecast = c.fetchPeriods(ctx2, url)
 			return nil
 		})
 	}
-	if p.ForecastHourly != "" {
-		url := p.ForecastHourly
+	if u := p.hourlyURL(c.baseURL); u != "" {
+		url := u
 		g.Go(func() error {
-			pr, err := c.fetchPeriods(ctx2, url)
-			if err == nil {
-				rHourly = pr
-			} else {
-				errHourly = err
-			}
+			rHourly, errHourly = c.fetchPeriods(ctx2, url)
 			return nil
 		})
 	}
 	_ = g.Wait()
 
-	if len(rForecast) == 0 && len(rHourly) == 0 {
-		return "", 0, fmt.Errorf("no forecast periods: dailyErr=%v hourlyErr=%v", errForecast, errHourly)
+	if len(rForecast.Periods) == 0 && len(rHourly.Periods) == 0 {
+		if err := errors.Join(errForecast, errHourly); err != nil {
+			return domain.TodayForecast{}, err
+		}
+		return domain.TodayForecast{}, badData("points: no forecast URLs")
+	}
+
+	now := c.now()
+	loc := p.location(rForecast.Periods)
+	if len(rForecast.Periods) == 0 {
+		loc = p.location(rHourly.Periods)
+	}
+	daily, okDaily := chooseToday(rForecast.Periods, now, loc)
+	hourly, okHourly := chooseToday(rHourly.Periods, now, loc)
+	obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
+
+	pick, expires := daily, rForecast.Expires
+	switch {
+	case okDaily:
+		if pick.HighF == nil {
+			pick.HighF = hourly.HighF
+		}
+		if pick.LowF == nil {
+			pick.LowF = hourly.LowF
+		}
+		if pick.RHPct == nil {
+			pick.RHPct = hourly.RHPct
+		}
+	case okHourly:
+		pick, expires = hourly, rHourly.Expires
+	default:
+		return domain.TodayForecast{}, badData("forecast: no periods available")
 	}
+	return domain.TodayForecast{
+		ShortForecast: pick.Short,
+		Temperature:   degF(pick.TempF),
+		FeelsLike:     domain.FeelsLike(degF(pick.TempF), pick.RHPct, &domain.Quantity{Value: pick.WindMph, Unit: domain.MilesPerHour}),
+		High:          degFPtr(pick.HighF),
+		Low:           degFPtr(pick.LowF),
+		Expires:       expires,
+	}, nil
+}
 
-	if short, tempF, ok := chooseToday(rForecast); ok {
+// GetForecast returns the day/night periods of the 7-day forecast covering
+// the first days calendar days.
+func (c *Client) GetForecast(ctx context.Context, lat, lon float64, days int) (domain.Forecast, error) {
+	start := time.Now()
+	defer func() {
+		obs.NWSRequestsTotal.Inc()
 		obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
-		return short, tempF, nil
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	p, err := c.resolvePoints(ctx, lat, lon)
+	if err != nil {
+		return domain.Forecast{}, err
 	}
-	if short, tempF, ok := chooseToday(rHourly); ok {
+	forecastURL := p.forecastURL(c.baseURL)
+	if forecastURL == "" {
+		return domain.Forecast{}, badData("points: no daily forecast URL")
+	}
+
+	doc, err := c.fetchPeriods(ctx, forecastURL)
+	if err != nil {
+		return domain.Forecast{}, err
+	}
+
+	periods := limitDays(doc.Periods, days)
+	out := domain.Forecast{Periods: make([]domain.ForecastPeriod, 0, len(periods)), Expires: doc.Expires}
+	for _, pr := range periods {
+		out.Periods = append(out.Periods, domain.ForecastPeriod{
+			Name:             pr.Name,
+			StartTime:        pr.Start,
+			EndTime:          pr.End,
+			IsDaytime:        pr.IsDaytime,
+			Temperature:      degF(normalizeF(pr.Temp, pr.Unit)),
+			ShortForecast:    pr.Short,
+			DetailedForecast: pr.Detailed,
+		})
+	}
+	return out, nil
+}
+
+// GetHourlyForecast returns the first hours entries of the hourly forecast.
+func (c *Client) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (domain.HourlyForecast, error) {
+	start := time.Now()
+	defer func() {
+		obs.NWSRequestsTotal.Inc()
 		obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
-		return short, tempF, nil
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	p, err := c.resolvePoints(ctx, lat, lon)
+	if err != nil {
+		return domain.HourlyForecast{}, err
+	}
+	hourlyURL := p.hourlyURL(c.baseURL)
+	if hourlyURL == "" {
+		return domain.HourlyForecast{}, badData("points: no hourly forecast URL")
 	}
 
-	obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
-	return "", 0, errors.New("no forecast periods available")
+	doc, err := c.fetchPeriods(ctx, hourlyURL)
+	if err != nil {
+		return domain.HourlyForecast{}, err
+	}
+	periods := doc.Periods
+	if hours > 0 && hours < len(periods) {
+		periods = periods[:hours]
+	}
+
+	out := domain.HourlyForecast{Hours: make([]domain.HourlyPeriod, 0, len(periods)), Expires: doc.Expires}
+	for _, pr := range periods {
+		out.Hours = append(out.Hours, domain.HourlyPeriod{
+			StartTime:                pr.Start,
+			EndTime:                  pr.End,
+			Temperature:              degF(normalizeF(pr.Temp, pr.Unit)),
+			WindSpeed:                domain.Quantity{Value: pr.WindMph, Unit: domain.MilesPerHour},
+			WindDirection:            pr.WindDir,
+			PrecipitationProbability: pr.PrecipPct,
+			ShortForecast:            pr.Short,
+		})
+	}
+	return out, nil
+}
+
+func (c *Client) resolvePoints(ctx context.Context, lat, lon float64) (pointsResp, error) {
+	key := fmt.Sprintf("%.4f,%.4f", lat, lon)
+	return c.points.GetOrLoad(ctx, key, func(ctx context.Context) (pointsResp, time.Duration, error) {
+		p, err := c.fetchPoints(ctx, lat, lon)
+		return p, 0, err
+	})
+}
+
+func (c *Client) fetchPoints(ctx context.Context, lat, lon float64) (pointsResp, error) {
+	pointsURL := fmt.Sprintf(c.baseURL+"/points/%f,%f", lat, lon)
+	resp, err := c.doNWS(ctx, http.MethodGet, pointsURL, nil)
+	if err != nil {
+		return pointsResp{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return pointsResp{}, problemFrom(resp, "points")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20)) // 1MB
+	if err != nil {
+		return pointsResp{}, fmt.Errorf("read points body: %w", err)
+	}
+
+	var p pointsResp
+	if err := json.Unmarshal(body, &p); err != nil {
+		return pointsResp{}, badData("points: %w", err)
+	}
+
+	if p.Forecast == "" && p.ForecastHourly == "" {
+		return pointsResp{}, badData("points: no forecast URLs")
+	}
+	return p, nil
 }
 
 type period struct {
-	Name  string
-	Temp  float64
-	Unit  string
-	Short string
+	Name      string
+	Start     time.Time
+	End       time.Time
+	IsDaytime bool
+	Temp      float64
+	Unit      string
+	Short     string
+	Detailed  string
+	WindMph   float64
+	WindDir   string
+	PrecipPct float64
+	RHPct     *float64
 }
 
-func (c *Client) fetchPeriods(ctx context.Context, url string) ([]period, error) {
-	resp, err := c.doNWS(ctx, http.MethodGet, url)
+// fetchPeriods downloads a forecast document. When a previous copy of it is
+// cached with validators the request is conditional, and a 304 renews that
+// copy instead of downloading it again.
+func (c *Client) fetchPeriods(ctx context.Context, url string) (periodsDoc, error) {
+	prev, _, cached := c.periods.GetStale(url)
+	var header http.Header
+	if cached {
+		header = prev.conditionalHeader()
+	}
+	resp, err := c.doNWS(ctx, http.MethodGet, url, header)
 	if err != nil {
-		return nil, err
+		return periodsDoc{}, err
 	}
 	defer resp.Body.Close()
 
+	if cached && header != nil {
+		if resp.StatusCode == http.StatusNotModified {
+			obs.NWSConditionalRequestsTotal.WithLabelValues("not_modified").Inc()
+			prev.Expires = expiresAt(resp.Header, time.Now())
+			c.periods.Set(url, prev)
+			return prev, nil
+		}
+		obs.NWSConditionalRequestsTotal.WithLabelValues("modified").Inc()
+	}
+
 	if resp.StatusCode >= 300 {
-		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		return nil, fmt.Errorf("forecast status: %d body: %s", resp.StatusCode, string(b))
+		return periodsDoc{}, problemFrom(resp, "forecast")
 	}
 
 	body, err := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
 	if err != nil {
-		return nil, fmt.Errorf("read forecast body: %w", err)
+		return periodsDoc{}, fmt.Errorf("read forecast body: %w", err)
+	}
+
+	periods, err := parsePeriods(url, body)
+	if err != nil {
+		return periodsDoc{}, err
+	}
+	doc := periodsDoc{
+		Periods:      periods,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Expires:      expiresAt(resp.Header, time.Now()),
 	}
+	if doc.ETag != "" || doc.LastModified != "" {
+		c.periods.Set(url, doc)
+	}
+	return doc, nil
+}
 
+func parsePeriods(url string, body []byte) ([]period, error) {
 	// 1) periods in top-level
 	var ft forecastTop
 	if err := json.Unmarshal(body, &ft); err == nil && len(ft.Periods) > 0 {
-		out := make([]period, 0, len(ft.Periods))
-		for _, pr := range ft.Periods {
-			out = append(out, period{
-				Name:  pr.Name,
-				Temp:  pr.Temperature,
-				Unit:  pr.TemperatureUnit,
-				Short: pr.ShortForecast,
-			})
-		}
-		return out, nil
+		return toPeriods(ft.Periods), nil
 	}
 
 	// 2) fallback: properties.periods
 	var fp forecastWithProps
 	if err := json.Unmarshal(body, &fp); err == nil && len(fp.Properties.Periods) > 0 {
-		out := make([]period, 0, len(fp.Properties.Periods))
-		for _, pr := range fp.Properties.Periods {
-			out = append(out, period{
-				Name:  pr.Name,
-				Temp:  pr.Temperature,
-				Unit:  pr.TemperatureUnit,
-				Short: pr.ShortForecast,
-			})
-		}
-		return out, nil
+		return toPeriods(fp.Properties.Periods), nil
 	}
 
-	return nil, fmt.Errorf("empty periods from %s; body=%s", url, string(body))
+	return nil, badData("forecast: no periods in %s", url)
 }
 
-func (c *Client) doNWS(ctx context.Context, method, url string) (*http.Response, error) {
-	req, _ := http.NewRequestWithContext(ctx, method, url, nil)
-	return c.http.Do(req)
+func (c *Client) getJSON(ctx context.Context, url, what string, out any) error {
+	resp, err := c.doNWS(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return problemFrom(resp, what)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+	if err != nil {
+		return fmt.Errorf("read %s body: %w", what, err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return badData("%s: %w", what, err)
+	}
+	return nil
 }
 
-func chooseToday(periods []period) (string, float64, bool) {
-	for _, pr := range periods {
-		n := strings.ToLower(pr.Name)
-		if n == "today" || strings.Contains(n, "today") ||
-			strings.Contains(n, "this morning") || strings.Contains(n, "this afternoon") ||
-			strings.Contains(n, "tonight") {
-			return pr.Short, normalizeF(pr.Temp, pr.Unit), true
+func toPeriods(in []forecastPeriod) []period {
+	out := make([]period, 0, len(in))
+	for _, pr := range in {
+		out = append(out, period{
+			Name:      pr.Name,
+			Start:     pr.StartTime,
+			End:       pr.EndTime,
+			IsDaytime: pr.IsDaytime,
+			Temp:      pr.Temperature,
+			Unit:      pr.TemperatureUnit,
+			Short:     pr.ShortForecast,
+			Detailed:  pr.DetailedForecast,
+			WindMph:   parseWindMph(pr.WindSpeed),
+			WindDir:   pr.WindDirection,
+			PrecipPct: valueOr(pr.ProbabilityOfPrecipitation, 0),
+			RHPct:     pr.RelativeHumidity.Value,
+		})
+	}
+	return out
+}
+
+// limitDays keeps the first days day/night pairs: each daytime period with
+// the night that follows it. A document starting in the evening leads with
+// a night, which belongs to the first day, so a full week is never cut.
+func limitDays(periods []period, days int) []period {
+	if days <= 0 || days >= domain.MaxForecastDays {
+		return periods
+	}
+	seen := 0
+	for i, pr := range periods {
+		if pr.IsDaytime {
+			seen++
+		}
+		if seen > days {
+			return periods[:i]
+		}
+	}
+	return periods
+}
+
+// parseWindMph reads NWS wind strings such as "10 mph", "5 to 10 mph" or
+// "15 km/h", keeping the upper bound of a range.
+func parseWindMph(s string) float64 {
+	fields := strings.Fields(strings.ToLower(s))
+	var v float64
+	unit := "mph"
+	for _, f := range fields {
+		if n, err := strconv.ParseFloat(f, 64); err == nil {
+			if n > v {
+				v = n
+			}
+			continue
 		}
+		if f == "km/h" || f == "kph" {
+			unit = "km/h"
+		}
+	}
+	if unit == "km/h" {
+		return v / 1.609344
 	}
-	if len(periods) > 0 {
-		pr := periods[0]
-		return pr.Short, normalizeF(pr.Temp, pr.Unit), true
+	return v
+}
+
+func valueOr(q quantValue, def float64) float64 {
+	if q.Value == nil {
+		return def
 	}
-	return "", 0, false
+	return *q.Value
 }
 
 func normalizeF(v float64, unit string) float64 {
 	if strings.ToUpper(unit) == "C" {
-		return (v * 9.0 / 5.0) + 32.0
+		return domain.Quantity{Value: v, Unit: domain.Celsius}.In(domain.Fahrenheit).Value
 	}
 	return v
 }
+
+func degF(v float64) domain.Quantity { return domain.Quantity{Value: v, Unit: domain.Fahrenheit} }
+
+func degFPtr(v *float64) *domain.Quantity {
+	if v == nil {
+		return nil
+	}
+	q := degF(*v)
+	return &q
+}
@@ -0,0 +1,473 @@
+package nws
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rcglezreyes/go_weather/internal/adapters/nws/nwstest"
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+	"github.com/rcglezreyes/go_weather/internal/pkg/breaker"
+	"github.com/rcglezreyes/go_weather/internal/pkg/cache"
+	"github.com/rcglezreyes/go_weather/internal/pkg/httpclient"
+)
+
+type rtFunc func(*http.Request) (*http.Response, error)
+
+func (f rtFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func jsonResp(body string) *http.Response {
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestGetForecast_CachesGridResolution(t *testing.T) {
+	hits := map[string]int{}
+	c := NewNWSClient(WithPointsCache(cache.NewTTLCache(cache.Config{TTL: 60, SweepInterval: 10, MaxEntries: 10})))
+	c.http = &http.Client{Transport: rtFunc(func(r *http.Request) (*http.Response, error) {
+		hits[r.URL.Path]++
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/points/"):
+			return jsonResp(`{"forecast":"https://example.invalid/old","gridId":"LWX","gridX":97,"gridY":71}`), nil
+		case r.URL.Path == "/gridpoints/LWX/97,71/forecast":
+			return jsonResp(`{"periods":[{"name":"Today","startTime":"2024-07-01T06:00:00-04:00","temperature":88,"temperatureUnit":"F"}]}`), nil
+		}
+		t.Fatalf("unexpected request %s", r.URL)
+		return nil, nil
+	})}
+
+	for i := 0; i < 2; i++ {
+		f, err := c.GetForecast(context.Background(), 38.8894, -77.0352, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(f.Periods) != 1 || f.Periods[0].Temperature.Value != 88 {
+			t.Fatalf("unexpected periods: %v", f.Periods)
+		}
+	}
+	if hits["/points/38.889400,-77.035200"] != 1 {
+		t.Fatalf("want 1 points call, got %v", hits)
+	}
+	if hits["/gridpoints/LWX/97,71/forecast"] != 2 {
+		t.Fatalf("want 2 gridpoints calls, got %v", hits)
+	}
+}
+
+func statusResp(code int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: code, Header: header, Body: io.NopCloser(strings.NewReader("busy"))}
+}
+
+func TestDoNWS_Retries(t *testing.T) {
+	fast := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	reset := errors.New("connection reset by peer")
+	cases := []struct {
+		name      string
+		method    string
+		responses []func() (*http.Response, error)
+		wantCalls int
+		wantCode  int
+	}{
+		{"transient 503 then ok", http.MethodGet, []func() (*http.Response, error){
+			func() (*http.Response, error) { return statusResp(503, nil), nil },
+			func() (*http.Response, error) { return jsonResp(`{}`), nil },
+		}, 2, 200},
+		{"connection reset then ok", http.MethodGet, []func() (*http.Response, error){
+			func() (*http.Response, error) { return nil, reset },
+			func() (*http.Response, error) { return jsonResp(`{}`), nil },
+		}, 2, 200},
+		{"gives up after max attempts", http.MethodGet, []func() (*http.Response, error){
+			func() (*http.Response, error) { return statusResp(502, nil), nil },
+		}, 3, 502},
+		{"404 is not retried", http.MethodGet, []func() (*http.Response, error){
+			func() (*http.Response, error) { return statusResp(404, nil), nil },
+		}, 1, 404},
+		{"POST is not retried", http.MethodPost, []func() (*http.Response, error){
+			func() (*http.Response, error) { return statusResp(503, nil), nil },
+		}, 1, 503},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			c := NewNWSClient(WithRetry(fast))
+			c.http = &http.Client{Transport: rtFunc(func(r *http.Request) (*http.Response, error) {
+				calls++
+				return tc.responses[min(calls, len(tc.responses))-1]()
+			})}
+			resp, err := c.doNWS(context.Background(), tc.method, "https://api.weather.gov/points/1,2", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if calls != tc.wantCalls || resp.StatusCode != tc.wantCode {
+				t.Fatalf("want %d calls ending in %d, got %d calls ending in %d", tc.wantCalls, tc.wantCode, calls, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestDoNWS_RetryAfterAndDeadline(t *testing.T) {
+	calls := 0
+	c := NewNWSClient(WithRetry(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}))
+	c.http = &http.Client{Transport: rtFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return statusResp(429, http.Header{"Retry-After": []string{"30"}}), nil
+	})}
+
+	// waiting 30s would overrun the deadline: return the 429 right away
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	resp, err := c.doNWS(ctx, http.MethodGet, "https://api.weather.gov/points/1,2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if calls != 1 || resp.StatusCode != 429 || time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("want an immediate 429 after 1 call, got %d calls, status %d", calls, resp.StatusCode)
+	}
+
+	if d, ok := retryAfter("2"); !ok || d != 2*time.Second {
+		t.Fatalf("retryAfter seconds: %v %v", d, ok)
+	}
+	if d, ok := retryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)); !ok || d < 58*time.Second {
+		t.Fatalf("retryAfter date: %v %v", d, ok)
+	}
+}
+
+func TestDoNWS_BreakerFailsFast(t *testing.T) {
+	calls := 0
+	c := NewNWSClient(WithRetry(RetryPolicy{MaxAttempts: 1}), WithBreaker(breaker.New(breaker.Config{FailureThreshold: 2, CoolDown: time.Hour})))
+	c.http = &http.Client{Transport: rtFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return statusResp(503, nil), nil
+	})}
+	for i := 0; i < 2; i++ {
+		resp, err := c.doNWS(context.Background(), http.MethodGet, "https://api.weather.gov/points/1,2", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	_, err := c.doNWS(context.Background(), http.MethodGet, "https://api.weather.gov/points/1,2", nil)
+	if !errors.Is(err, domain.ErrUpstreamUnavailable) || !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("want a fast failure, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("want no call while open, got %d calls", calls)
+	}
+}
+
+func TestClient_AgainstFakeNWS(t *testing.T) {
+	srv := nwstest.NewServer()
+	defer srv.Close()
+	c := NewNWSClient(WithBaseURL(srv.URL), WithRetry(RetryPolicy{MaxAttempts: 1}))
+	c.now = func() time.Time { return time.Date(2024, 7, 1, 14, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+	lat, lon := 38.8894, -77.0352
+
+	today, err := c.GetToday(ctx, lat, lon)
+	if err != nil || today.ShortForecast != "Sunny" || today.Temperature != (domain.Quantity{Value: 88, Unit: domain.Fahrenheit}) ||
+		today.High == nil || today.High.Value != 88 || today.Low == nil || today.Low.Value != 68 ||
+		math.Round(today.FeelsLike.Value) != 100 {
+		t.Fatalf("GetToday: %+v %v", today, err)
+	}
+	days, err := c.GetForecast(ctx, lat, lon, 2)
+	if err != nil || len(days.Periods) != 4 {
+		t.Fatalf("GetForecast: %d periods, %v", len(days.Periods), err)
+	}
+	hours, err := c.GetHourlyForecast(ctx, lat, lon, 12)
+	if err != nil || len(hours.Hours) != 12 {
+		t.Fatalf("GetHourlyForecast: %d hours, %v", len(hours.Hours), err)
+	}
+	alerts, err := c.GetActiveAlerts(ctx, domain.AlertQuery{Zone: "DCZ001"})
+	if err != nil || len(alerts) != 1 || alerts[0].Event != "Heat Advisory" {
+		t.Fatalf("GetActiveAlerts: %+v %v", alerts, err)
+	}
+	cc, err := c.GetCurrentConditions(ctx, lat, lon)
+	if err != nil || cc.StationID != "KDCA" || cc.Temperature == nil || cc.Temperature.Unit != domain.Celsius || cc.FeelsLike == nil {
+		t.Fatalf("GetCurrentConditions: %+v %v", cc, err)
+	}
+
+	srv.SetStatus(nwstest.Alerts, http.StatusInternalServerError)
+	if _, err := c.GetActiveAlerts(ctx, domain.AlertQuery{Zone: "DCZ001"}); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("want a 500 error, got %v", err)
+	}
+	srv.SetMalformed(nwstest.Observation, true)
+	if _, err := c.GetCurrentConditions(ctx, lat, lon); err == nil {
+		t.Fatal("want an error for a malformed body")
+	}
+	srv.SetLatency(nwstest.All, 200*time.Millisecond)
+	tctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetForecast(tctx, lat, lon, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want a timeout, got %v", err)
+	}
+}
+
+func TestClient_ReplaysRecordedTraffic(t *testing.T) {
+	dir := t.TempDir()
+	srv := nwstest.NewServer()
+	rec := NewNWSClient(WithBaseURL(srv.URL), WithHTTPClient(httpclient.New(UserAgent, httpclient.WithCassette(httpclient.Record, dir))))
+	want, err := rec.GetToday(context.Background(), 38.8894, -77.0352)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	play := NewNWSClient(WithBaseURL(srv.URL), WithHTTPClient(httpclient.New(UserAgent, httpclient.WithCassette(httpclient.Replay, dir))))
+	got, err := play.GetToday(context.Background(), 38.8894, -77.0352)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ShortForecast != want.ShortForecast || got.Temperature != want.Temperature {
+		t.Fatalf("replayed %+v, recorded %+v", got, want)
+	}
+}
+
+// A second fetch of a cached document is conditional; its 304 must not
+// replace the recorded document.
+func TestClient_ReplaysRecordedConditionalRequests(t *testing.T) {
+	dir := t.TempDir()
+	srv := nwstest.NewServer()
+	client := func(mode httpclient.CassetteMode) *Client {
+		return NewNWSClient(WithBaseURL(srv.URL), WithRetry(RetryPolicy{MaxAttempts: 1}),
+			WithValidatorCache(cache.NewTTLCache(cache.Config{TTL: 3600, MaxEntries: 10})),
+			WithHTTPClient(httpclient.New(UserAgent, httpclient.WithCassette(mode, dir))))
+	}
+	rec := client(httpclient.Record)
+	for i := 0; i < 2; i++ {
+		if _, err := rec.GetForecast(context.Background(), 38.8894, -77.0352, 7); err != nil {
+			t.Fatalf("record %d: %v", i, err)
+		}
+	}
+	if n := srv.NotModified(nwstest.Forecast); n != 0 {
+		t.Fatalf("want no conditional request while recording, got %d 304s", n)
+	}
+	srv.Close()
+
+	play := client(httpclient.Replay)
+	for i := 0; i < 2; i++ {
+		got, err := play.GetForecast(context.Background(), 38.8894, -77.0352, 7)
+		if err != nil || len(got.Periods) == 0 {
+			t.Fatalf("replay %d: %d periods, %v", i, len(got.Periods), err)
+		}
+	}
+}
+
+func TestFetchPeriods_ConditionalRequests(t *testing.T) {
+	srv := nwstest.NewServer()
+	defer srv.Close()
+	c := NewNWSClient(WithBaseURL(srv.URL), WithValidatorCache(cache.NewTTLCache(cache.Config{TTL: 3600, MaxEntries: 10})))
+	ctx := context.Background()
+
+	first, err := c.GetForecast(ctx, 38.8894, -77.0352, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl := time.Until(first.Expires); ttl < 590*time.Second || ttl > 600*time.Second {
+		t.Fatalf("want the expiry from max-age=600, got %v", ttl)
+	}
+
+	// unchanged upstream: 304, the cached document is renewed
+	second, err := c.GetForecast(ctx, 38.8894, -77.0352, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srv.NotModified(nwstest.Forecast) != 1 || len(second.Periods) != len(first.Periods) || !second.Expires.After(first.Expires.Add(-time.Second)) {
+		t.Fatalf("want a renewed copy from a 304, got %d 304s and %+v", srv.NotModified(nwstest.Forecast), second)
+	}
+
+	// changed upstream: the new document is downloaded
+	srv.SetFixture(nwstest.Forecast, []byte(`{"periods":[{"name":"Today","startTime":"2024-07-01T06:00:00-04:00","temperature":60,"temperatureUnit":"F"}]}`))
+	third, err := c.GetForecast(ctx, 38.8894, -77.0352, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srv.NotModified(nwstest.Forecast) != 1 || len(third.Periods) != 1 || third.Periods[0].Temperature.Value != 60 {
+		t.Fatalf("want the changed forecast, got %+v", third)
+	}
+}
+
+func TestRegisterCacheTypes_DropsUnversionedEntries(t *testing.T) {
+	codec := cache.NewJSONCodec()
+	RegisterCacheTypes(codec)
+	b, err := codec.Marshal(pointsResp{TimeZone: "America/New_York"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := codec.Unmarshal(b); err != nil || got.(pointsResp).TimeZone != "America/New_York" {
+		t.Fatalf("round trip: %+v, %v", got, err)
+	}
+	// written before points recorded their time zone
+	if _, err := codec.Unmarshal([]byte(`{"t":"points","v":{}}`)); err == nil {
+		t.Fatal("want entries of the old points type dropped")
+	}
+}
+
+func TestExpiresAt(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name   string
+		header http.Header
+		want   time.Time
+	}{
+		{"max-age", http.Header{"Cache-Control": {"public, max-age=900, s-maxage=3600"}}, now.Add(15 * time.Minute)},
+		{"max-age minus age", http.Header{"Cache-Control": {"max-age=900"}, "Age": {"300"}}, now.Add(10 * time.Minute)},
+		{"expires against server date", http.Header{
+			"Expires": {"Mon, 01 Jul 2024 10:30:00 GMT"}, "Date": {"Mon, 01 Jul 2024 10:00:00 GMT"},
+		}, now.Add(30 * time.Minute)},
+		{"no-cache", http.Header{"Cache-Control": {"no-cache"}, "Expires": {"Mon, 01 Jul 2024 13:00:00 GMT"}}, time.Time{}},
+		{"nothing", http.Header{}, time.Time{}},
+	}
+	for _, tc := range cases {
+		if got := expiresAt(tc.header, now); !got.Equal(tc.want) {
+			t.Errorf("%s: want %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestClient_ProblemErrors(t *testing.T) {
+	outside := `{"correlationId":"1c6c1c2a","title":"Data Unavailable For Requested Point","type":"https://api.weather.gov/problems/InvalidPoint","status":404,"detail":"Unable to provide data for requested point 51.5,-0.12","instance":"https://api.weather.gov/requests/1c6c1c2a"}`
+	cases := []struct {
+		name string
+		resp func() *http.Response
+		want error
+	}{
+		{"outside coverage", func() *http.Response {
+			r := statusResp(http.StatusNotFound, http.Header{"Content-Type": {"application/problem+json"}})
+			r.Body = io.NopCloser(strings.NewReader(outside))
+			return r
+		}, domain.ErrNotFound},
+		{"rate limited", func() *http.Response { return statusResp(http.StatusTooManyRequests, nil) }, domain.ErrRateLimited},
+		{"server error", func() *http.Response { return statusResp(http.StatusInternalServerError, nil) }, domain.ErrUpstreamUnavailable},
+		{"unexpected status", func() *http.Response { return statusResp(http.StatusForbidden, nil) }, domain.ErrBadUpstreamData},
+		{"malformed body", func() *http.Response { return jsonResp(`{"forecast":`) }, domain.ErrBadUpstreamData},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewNWSClient(WithRetry(RetryPolicy{MaxAttempts: 1}))
+			c.http = &http.Client{Transport: rtFunc(func(r *http.Request) (*http.Response, error) { return tc.resp(), nil })}
+			_, err := c.GetForecast(context.Background(), 51.5, -0.12, 7)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("want %v, got %v", tc.want, err)
+			}
+			if strings.Contains(err.Error(), "busy") || strings.Contains(err.Error(), `{"forecast"`) {
+				t.Fatalf("upstream body leaked into %q", err)
+			}
+		})
+	}
+
+	c := NewNWSClient()
+	c.http = &http.Client{Transport: rtFunc(func(r *http.Request) (*http.Response, error) { return cases[0].resp(), nil })}
+	_, err := c.GetToday(context.Background(), 51.5, -0.12)
+	var pe *ProblemError
+	if !errors.As(err, &pe) || pe.Status != 404 || pe.CorrelationID != "1c6c1c2a" || pe.Title != "Data Unavailable For Requested Point" {
+		t.Fatalf("want the parsed problem, got %#v", err)
+	}
+}
+
+func TestLimitDays_StartingAtNight(t *testing.T) {
+	start := time.Date(2024, 7, 1, 18, 0, 0, 0, time.FixedZone("CDT", -5*3600))
+	var week []period
+	for i := 0; i < 14; i++ {
+		from := start.Add(time.Duration(i) * 12 * time.Hour)
+		week = append(week, period{Start: from, End: from.Add(12 * time.Hour), IsDaytime: i%2 == 1})
+	}
+	for _, tc := range []struct{ days, want int }{{7, 14}, {1, 3}, {3, 7}, {0, 14}} {
+		got := limitDays(week, tc.days)
+		if len(got) != tc.want {
+			t.Errorf("days=%d: want %d periods, got %d", tc.days, tc.want, len(got))
+		}
+		if n := len(got); n > 0 && got[n-1].IsDaytime && n < len(week) {
+			t.Errorf("days=%d: cut between a day and its night", tc.days)
+		}
+	}
+}
+
+func TestChooseToday(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := func(y int, m time.Month, d, h, min int) time.Time { return time.Date(y, m, d, h, min, 0, 0, ny) }
+	pr := func(from, to time.Time, day bool, temp float64) period {
+		return period{Start: from, End: to, IsDaytime: day, Temp: temp, Unit: "F", Short: "x"}
+	}
+	f := func(v float64) *float64 { return &v }
+	july := []period{
+		pr(at(2024, 7, 1, 6, 0), at(2024, 7, 1, 18, 0), true, 88),
+		pr(at(2024, 7, 1, 18, 0), at(2024, 7, 2, 6, 0), false, 68),
+		pr(at(2024, 7, 2, 6, 0), at(2024, 7, 2, 18, 0), true, 91),
+		pr(at(2024, 7, 2, 18, 0), at(2024, 7, 3, 6, 0), false, 73),
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		loc       *time.Location
+		periods   []period
+		temp      float64
+		high, low *float64
+	}{
+		{"afternoon", at(2024, 7, 1, 15, 0), ny, july, 88, f(88), f(68)},
+		{"before midnight", at(2024, 7, 1, 23, 30), ny, july[1:], 68, nil, f(68)},
+		{"before midnight, past midnight in UTC", at(2024, 7, 1, 22, 0), ny, july[1:], 68, nil, f(68)},
+		{"same instant read in UTC", at(2024, 7, 1, 22, 0), time.UTC, july[1:], 68, f(91), f(73)},
+		{"after midnight", at(2024, 7, 2, 0, 30), ny, []period{
+			pr(at(2024, 7, 2, 0, 0), at(2024, 7, 2, 6, 0), false, 67),
+			july[2], july[3],
+		}, 67, f(91), f(73)},
+		{"spring forward overnight", at(2024, 3, 10, 1, 30), ny, []period{
+			pr(at(2024, 3, 10, 0, 0), at(2024, 3, 10, 6, 0), false, 30),
+			pr(at(2024, 3, 10, 6, 0), at(2024, 3, 10, 18, 0), true, 55),
+			pr(at(2024, 3, 10, 18, 0), at(2024, 3, 11, 6, 0), false, 35),
+		}, 30, f(55), f(35)},
+		{"spring forward late evening", at(2024, 3, 10, 23, 30), ny, []period{
+			pr(at(2024, 3, 10, 18, 0), at(2024, 3, 11, 6, 0), false, 35),
+			pr(at(2024, 3, 11, 6, 0), at(2024, 3, 11, 18, 0), true, 60),
+		}, 35, nil, f(35)},
+		{"fall back, second 01:30", at(2024, 11, 3, 1, 30).Add(time.Hour), ny, []period{
+			pr(at(2024, 11, 3, 1, 0), at(2024, 11, 3, 6, 0), false, 40),
+			pr(at(2024, 11, 3, 6, 0), at(2024, 11, 3, 18, 0), true, 58),
+			pr(at(2024, 11, 3, 18, 0), at(2024, 11, 4, 6, 0), false, 42),
+		}, 40, f(58), f(42)},
+		{"hourly", at(2024, 7, 1, 10, 0), ny, []period{
+			pr(at(2024, 7, 1, 9, 0), at(2024, 7, 1, 10, 0), true, 79),
+			pr(at(2024, 7, 1, 10, 0), at(2024, 7, 1, 11, 0), true, 80),
+			pr(at(2024, 7, 1, 14, 0), at(2024, 7, 1, 15, 0), true, 90),
+			pr(at(2024, 7, 1, 21, 0), at(2024, 7, 1, 22, 0), false, 75),
+			pr(at(2024, 7, 2, 4, 0), at(2024, 7, 2, 5, 0), false, 66),
+			pr(at(2024, 7, 2, 13, 0), at(2024, 7, 2, 14, 0), true, 95),
+		}, 80, f(90), f(66)},
+		{"outdated document", at(2026, 1, 1, 12, 0), ny, july, 88, nil, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := chooseToday(tc.periods, tc.now, tc.loc)
+			if !ok || got.TempF != tc.temp || !sameTemp(got.HighF, tc.high) || !sameTemp(got.LowF, tc.low) {
+				t.Fatalf("got %+v (high %v, low %v), want %v high %v low %v", got, deref(got.HighF), deref(got.LowF), tc.temp, deref(tc.high), deref(tc.low))
+			}
+		})
+	}
+	if _, ok := chooseToday(nil, time.Now(), ny); ok {
+		t.Fatal("want no pick without periods")
+	}
+}
+
+func sameTemp(a, b *float64) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func deref(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
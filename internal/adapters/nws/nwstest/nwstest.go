@@ -0,0 +1,198 @@
+// Package nwstest provides a fake of the NWS API for tests and offline
+// development. It serves the points, forecast, hourly forecast, active alerts,
+// observation stations and latest observation endpoints from fixtures, for
+// any coordinates, with knobs to add latency, fail with a status code or
+// return malformed bodies. Like NWS it sends ETag, Last-Modified and
+// Cache-Control headers and answers matching conditional requests with 304.
+package nwstest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Endpoint names a group of NWS API routes.
+type Endpoint string
+
+const (
+	Points      Endpoint = "points"      // /points/{lat},{lon}
+	Forecast    Endpoint = "forecast"    // /gridpoints/{office}/{x},{y}/forecast
+	Hourly      Endpoint = "hourly"      // /gridpoints/{office}/{x},{y}/forecast/hourly
+	Alerts      Endpoint = "alerts"      // /alerts/active
+	Stations    Endpoint = "stations"    // /gridpoints/{office}/{x},{y}/stations
+	Observation Endpoint = "observation" // /stations/{id}/observations/latest
+
+	// All applies a knob to every endpoint.
+	All Endpoint = "*"
+)
+
+var endpoints = []Endpoint{Points, Forecast, Hourly, Alerts, Stations, Observation}
+
+// upstreamURL is rewritten to the fake's own address in the fixtures, so
+// that clients following links keep talking to the fake.
+const upstreamURL = "https://api.weather.gov"
+
+const lastModified = "Mon, 01 Jul 2024 09:47:59 GMT"
+
+// NWS is an http.Handler faking the NWS API. Knobs may be changed while it
+// serves requests.
+type NWS struct {
+	mux *http.ServeMux
+
+	mu        sync.Mutex
+	bodies    map[Endpoint][]byte
+	latency   map[Endpoint]time.Duration
+	status    map[Endpoint]int
+	malformed map[Endpoint]bool
+	requests  map[Endpoint]int
+	notMod    map[Endpoint]int
+}
+
+// New returns a fake serving the bundled fixtures: a Washington, DC grid
+// point, its forecasts, a heat advisory and three nearby stations.
+func New() *NWS {
+	n := &NWS{
+		mux:       http.NewServeMux(),
+		bodies:    map[Endpoint][]byte{},
+		latency:   map[Endpoint]time.Duration{},
+		status:    map[Endpoint]int{},
+		malformed: map[Endpoint]bool{},
+		requests:  map[Endpoint]int{},
+		notMod:    map[Endpoint]int{},
+	}
+	for _, e := range endpoints {
+		b, err := fixtures.ReadFile("fixtures/" + string(e) + ".json")
+		if err != nil {
+			panic(err)
+		}
+		n.bodies[e] = b
+	}
+	n.handle("GET /points/{point}", Points)
+	n.handle("GET /gridpoints/{office}/{grid}/forecast", Forecast)
+	n.handle("GET /gridpoints/{office}/{grid}/forecast/hourly", Hourly)
+	n.handle("GET /gridpoints/{office}/{grid}/stations", Stations)
+	n.handle("GET /alerts/active", Alerts)
+	n.handle("GET /stations/{id}/observations/latest", Observation)
+	return n
+}
+
+func (n *NWS) ServeHTTP(w http.ResponseWriter, r *http.Request) { n.mux.ServeHTTP(w, r) }
+
+// SetFixture replaces the body served by e.
+func (n *NWS) SetFixture(e Endpoint, body []byte) {
+	n.set(e, func(e Endpoint) { n.bodies[e] = body })
+}
+
+// SetLatency delays the responses of e by d.
+func (n *NWS) SetLatency(e Endpoint, d time.Duration) {
+	n.set(e, func(e Endpoint) { n.latency[e] = d })
+}
+
+// SetStatus makes e fail with code; 0 restores the fixture.
+func (n *NWS) SetStatus(e Endpoint, code int) {
+	n.set(e, func(e Endpoint) { n.status[e] = code })
+}
+
+// SetMalformed makes e answer 200 with a truncated JSON body.
+func (n *NWS) SetMalformed(e Endpoint, malformed bool) {
+	n.set(e, func(e Endpoint) { n.malformed[e] = malformed })
+}
+
+// Requests returns how many requests e received.
+func (n *NWS) Requests(e Endpoint) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if e == All {
+		total := 0
+		for _, c := range n.requests {
+			total += c
+		}
+		return total
+	}
+	return n.requests[e]
+}
+
+// NotModified returns how many requests to e were answered with 304.
+func (n *NWS) NotModified(e Endpoint) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.notMod[e]
+}
+
+func (n *NWS) set(e Endpoint, f func(Endpoint)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if e != All {
+		f(e)
+		return
+	}
+	for _, e := range endpoints {
+		f(e)
+	}
+}
+
+func (n *NWS) handle(pattern string, e Endpoint) {
+	n.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		n.mu.Lock()
+		n.requests[e]++
+		body, latency, status, malformed := n.bodies[e], n.latency[e], n.status[e], n.malformed[e]
+		n.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if status != 0 {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"type":"https://api.weather.gov/problems/Nwstest","title":%q,"status":%d,"detail":"Injected by nwstest","instance":"https://api.weather.gov/requests/nwstest","correlationId":"nwstest"}`,
+				http.StatusText(status), status)
+			return
+		}
+
+		body = bytes.ReplaceAll(body, []byte(upstreamURL), []byte("http://"+r.Host))
+		if malformed {
+			body = body[:len(body)/2]
+		}
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		w.Header().Set("Cache-Control", "public, max-age=600")
+		if r.Header.Get("If-None-Match") == etag {
+			n.mu.Lock()
+			n.notMod[e]++
+			n.mu.Unlock()
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/ld+json")
+		_, _ = w.Write(body)
+	})
+}
+
+// Server is a fake NWS listening on a local address.
+type Server struct {
+	*httptest.Server
+	*NWS
+}
+
+// NewServer starts a fake NWS; point the client at its URL and Close it when
+// done.
+func NewServer() *Server {
+	n := New()
+	return &Server{Server: httptest.NewServer(n), NWS: n}
+}
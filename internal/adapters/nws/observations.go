@@ -0,0 +1,160 @@
+package nws
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+	obs "github.com/rcglezreyes/go_weather/observability/metrics"
+)
+
+// GetCurrentConditions returns the latest observation of the observation
+// station nearest to the point.
+func (c *Client) GetCurrentConditions(ctx context.Context, lat, lon float64) (domain.CurrentConditions, error) {
+	start := time.Now()
+	defer func() {
+		obs.NWSRequestsTotal.Inc()
+		obs.NWSRequestDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	p, err := c.resolvePoints(ctx, lat, lon)
+	if err != nil {
+		return domain.CurrentConditions{}, err
+	}
+	stationsURL := p.stationsURL(c.baseURL)
+	if stationsURL == "" {
+		return domain.CurrentConditions{}, badData("points: no observation stations URL")
+	}
+
+	st, distKm, err := c.nearestStation(ctx, stationsURL, lat, lon)
+	if err != nil {
+		return domain.CurrentConditions{}, err
+	}
+
+	var o observationResp
+	obsURL := c.baseURL + "/stations/" + url.PathEscape(st.StationIdentifier) + "/observations/latest"
+	if err := c.getJSON(ctx, obsURL, "observation", &o); err != nil {
+		return domain.CurrentConditions{}, err
+	}
+	props := o.observationProps
+	if o.Properties != nil {
+		props = *o.Properties
+	}
+
+	cc := domain.CurrentConditions{
+		StationID:        st.StationIdentifier,
+		StationName:      st.Name,
+		StationDistance:  domain.Quantity{Value: distKm, Unit: domain.Kilometers},
+		ObservedAt:       props.Timestamp,
+		TextDescription:  props.TextDescription,
+		Temperature:      props.Temperature.quantity(),
+		Dewpoint:         props.Dewpoint.quantity(),
+		RelativeHumidity: props.RelativeHumidity.Value,
+		WindSpeed:        props.WindSpeed.quantity(),
+		WindDirectionDeg: props.WindDirection.Value,
+		Pressure:         props.BarometricPressure.quantity(),
+		Visibility:       props.Visibility.quantity(),
+	}
+	if cc.Temperature != nil {
+		fl := domain.FeelsLike(*cc.Temperature, cc.RelativeHumidity, cc.WindSpeed)
+		cc.FeelsLike = &fl
+	}
+	return cc, nil
+}
+
+func (c *Client) nearestStation(ctx context.Context, stationsURL string, lat, lon float64) (station, float64, error) {
+	var sc stationsCollection
+	if err := c.getJSON(ctx, stationsURL, "stations", &sc); err != nil {
+		return station{}, 0, err
+	}
+
+	type candidate struct {
+		st       station
+		lat, lon float64
+		ok       bool
+	}
+	var cands []candidate
+	for _, st := range sc.Graph {
+		sLon, sLat, ok := parseWKTPoint(st.Geometry)
+		cands = append(cands, candidate{st: st, lat: sLat, lon: sLon, ok: ok})
+	}
+	for _, f := range sc.Features {
+		cd := candidate{st: f.Properties}
+		if len(f.Geometry.Coordinates) >= 2 {
+			cd.lon, cd.lat, cd.ok = f.Geometry.Coordinates[0], f.Geometry.Coordinates[1], true
+		}
+		cands = append(cands, cd)
+	}
+	if len(cands) == 0 {
+		return station{}, 0, fmt.Errorf("nws stations: no observation station near the point: %w", domain.ErrNotFound)
+	}
+
+	// NWS lists stations by proximity; prefer the nearest one we can measure
+	best, bestKm := cands[0].st, math.Inf(1)
+	for _, cd := range cands {
+		if !cd.ok {
+			continue
+		}
+		if d := haversineKm(lat, lon, cd.lat, cd.lon); d < bestKm {
+			best, bestKm = cd.st, d
+		}
+	}
+	if math.IsInf(bestKm, 1) {
+		bestKm = 0
+	}
+	return best, bestKm, nil
+}
+
+// parseWKTPoint parses "POINT(lon lat)".
+func parseWKTPoint(s string) (lon, lat float64, ok bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(strings.ToUpper(s), "POINT(") || !strings.HasSuffix(s, ")") {
+		return 0, 0, false
+	}
+	f := strings.Fields(s[len("POINT(") : len(s)-1])
+	if len(f) != 2 {
+		return 0, 0, false
+	}
+	lon, err1 := strconv.ParseFloat(f[0], 64)
+	lat, err2 := strconv.ParseFloat(f[1], 64)
+	return lon, lat, err1 == nil && err2 == nil
+}
+
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	rad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat, dLon := rad(lat2-lat1), rad(lon2-lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// wmoUnits maps the WMO unit codes of observations to domain units.
+var wmoUnits = map[string]domain.Unit{
+	"degC":   domain.Celsius,
+	"degF":   domain.Fahrenheit,
+	"km_h-1": domain.KilometersPerHour,
+	"m_s-1":  domain.MetersPerSecond,
+	"Pa":     domain.Pascals,
+	"hPa":    domain.Hectopascals,
+	"m":      domain.Meters,
+	"km":     domain.Kilometers,
+}
+
+// quantity tags a WMO quantitative value with its unit, keeping nulls and
+// values in units we do not know as nil.
+func (q quantValue) quantity() *domain.Quantity {
+	u, ok := wmoUnits[strings.TrimPrefix(q.UnitCode, "wmoUnit:")]
+	if q.Value == nil || !ok {
+		return nil
+	}
+	return &domain.Quantity{Value: *q.Value, Unit: u}
+}
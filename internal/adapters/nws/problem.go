@@ -0,0 +1,76 @@
+package nws
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+)
+
+// ProblemError is a non-2xx NWS response, described by the
+// application/problem+json body NWS sends along. It matches, with errors.Is,
+// the domain error its status maps to.
+type ProblemError struct {
+	// What names the resource requested, e.g. "points".
+	What          string
+	Status        int
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Detail        string `json:"detail"`
+	Instance      string `json:"instance"`
+	CorrelationID string `json:"correlationId"`
+}
+
+func (e *ProblemError) Error() string {
+	msg := fmt.Sprintf("nws %s: %d", e.What, e.Status)
+	if e.Title != "" {
+		msg += " " + e.Title
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.CorrelationID != "" {
+		msg += " (correlation id " + e.CorrelationID + ")"
+	}
+	return msg
+}
+
+func (e *ProblemError) Is(target error) bool { return target == e.kind() }
+
+func (e *ProblemError) kind() error {
+	switch {
+	case e.Status == http.StatusNotFound:
+		return domain.ErrNotFound
+	case e.Status == http.StatusTooManyRequests:
+		return domain.ErrRateLimited
+	case e.Status >= 500:
+		return domain.ErrUpstreamUnavailable
+	}
+	return domain.ErrBadUpstreamData
+}
+
+// problemFrom builds the error for a non-2xx response. Bodies that are not
+// problem+json are not echoed back, only the status is reported.
+func problemFrom(resp *http.Response, what string) error {
+	e := &ProblemError{What: what}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	_ = json.Unmarshal(b, e)
+	e.What, e.Status = what, resp.StatusCode
+	if e.Title == "" {
+		e.Title = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
+
+// dataError is a 2xx response we cannot use.
+type dataError struct{ err error }
+
+func badData(format string, args ...any) error {
+	return dataError{fmt.Errorf("nws "+format, args...)}
+}
+
+func (e dataError) Error() string        { return e.err.Error() }
+func (e dataError) Unwrap() error        { return e.err }
+func (e dataError) Is(target error) bool { return target == domain.ErrBadUpstreamData }
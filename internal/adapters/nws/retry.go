@@ -0,0 +1,133 @@
+package nws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+	obs "github.com/rcglezreyes/go_weather/observability/metrics"
+)
+
+// RetryPolicy controls how transient NWS failures are retried. Only GET and
+// HEAD requests are retried, on connection errors and 429/5xx gateway
+// statuses, and never past the caller's context deadline.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt; 1 disables retries.
+	MaxAttempts int
+	// BaseDelay doubles on every retry up to MaxDelay, with jitter. A
+	// Retry-After header takes precedence.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+// WithRetry replaces DefaultRetryPolicy.
+func WithRetry(p RetryPolicy) Option {
+	return func(cl *Client) { cl.retry = p }
+}
+
+// doNWS sends the request, with the extra header if any, through the circuit
+// breaker and the retry policy.
+func (c *Client) doNWS(ctx context.Context, method, url string, header http.Header) (*http.Response, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, fmt.Errorf("nws: %w: %w", domain.ErrUpstreamUnavailable, err)
+	}
+	resp, err := c.doWithRetry(ctx, method, url, header)
+	switch {
+	case err != nil:
+		c.breaker.Done(err)
+		err = fmt.Errorf("nws: %w: %w", domain.ErrUpstreamUnavailable, err)
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		c.breaker.Done(fmt.Errorf("nws status %d", resp.StatusCode))
+	default:
+		c.breaker.Done(nil)
+	}
+	return resp, err
+}
+
+func (c *Client) doWithRetry(ctx context.Context, method, url string, header http.Header) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		req, _ := http.NewRequestWithContext(ctx, method, url, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := c.http.Do(req)
+		if attempt >= c.retry.MaxAttempts || !idempotent(method) {
+			return resp, err
+		}
+		reason, ok := retryable(ctx, resp, err)
+		if !ok {
+			return resp, err
+		}
+		wait := c.retry.delay(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+		obs.NWSRetriesTotal.WithLabelValues(reason).Inc()
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// retryable reports whether the outcome of an attempt is worth retrying and
+// why, for the retries metric.
+func retryable(ctx context.Context, resp *http.Response, err error) (string, bool) {
+	if err != nil {
+		// the caller gave up: retrying cannot help
+		return "error", ctx.Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return strconv.Itoa(resp.StatusCode), true
+	}
+	return "", false
+}
+
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	d := p.MaxDelay
+	if attempt < 32 {
+		d = min(p.BaseDelay<<(attempt-1), p.MaxDelay)
+	}
+	// equal jitter: at least half the backoff, so retries still spread out
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryAfter parses a Retry-After header in seconds or as an HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
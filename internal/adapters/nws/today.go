@@ -0,0 +1,81 @@
+package nws
+
+import "time"
+
+// todayPick is what GetToday reports out of one forecast document.
+type todayPick struct {
+	Short   string
+	TempF   float64
+	HighF   *float64
+	LowF    *float64
+	RHPct   *float64
+	WindMph float64
+}
+
+// chooseToday picks, as of now in the location's time zone, the period in
+// effect, today's daytime high and tonight's low. Today is the local
+// calendar date of now; tonight is the night starting between noon today
+// and noon tomorrow, so past midnight the night that is ending does not count.
+// Hourly documents contribute their highest daytime and lowest night hour.
+// A document whose periods all ended is still used for its first period,
+// without high and low.
+func chooseToday(periods []period, now time.Time, loc *time.Location) (todayPick, bool) {
+	if len(periods) == 0 {
+		return todayPick{}, false
+	}
+	cur := -1
+	for i, pr := range periods {
+		if pr.End.After(now) {
+			cur = i
+			break
+		}
+	}
+	if cur < 0 {
+		return current(periods[0]), true
+	}
+	pick := current(periods[cur])
+
+	local := now.In(loc)
+	y, m, d := local.Date()
+	dayStart := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	dayEnd := time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+	nightStart := time.Date(y, m, d, 12, 0, 0, 0, loc)
+	nightEnd := time.Date(y, m, d+1, 12, 0, 0, 0, loc)
+
+	for _, pr := range periods[cur:] {
+		t := normalizeF(pr.Temp, pr.Unit)
+		switch {
+		case pr.IsDaytime && within(pr.Start, dayStart, dayEnd):
+			if pick.HighF == nil || t > *pick.HighF {
+				pick.HighF = &t
+			}
+		case !pr.IsDaytime && within(pr.Start, nightStart, nightEnd):
+			if pick.LowF == nil || t < *pick.LowF {
+				pick.LowF = &t
+			}
+		}
+	}
+	return pick, true
+}
+
+func current(pr period) todayPick {
+	return todayPick{Short: pr.Short, TempF: normalizeF(pr.Temp, pr.Unit), RHPct: pr.RHPct, WindMph: pr.WindMph}
+}
+
+func within(t, from, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
+
+// location returns the time zone NWS reports for the point or, for points
+// cached before it was recorded, the UTC offset of the first period.
+func (p pointsResp) location(periods []period) *time.Location {
+	if p.TimeZone != "" {
+		if loc, err := time.LoadLocation(p.TimeZone); err == nil {
+			return loc
+		}
+	}
+	if len(periods) > 0 {
+		return periods[0].Start.Location()
+	}
+	return time.UTC
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+)
+
+// DefaultCategoryProfile is the profile used when a request names none.
+const DefaultCategoryProfile = "default"
+
+var profileNameRe = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// CategoryProfile labels temperatures. Labels run from coldest to hottest and
+// Thresholds holds the °F at which each label after the first starts, so
+// there is one threshold less than labels.
+type CategoryProfile struct {
+	Name       string
+	Labels     []string
+	Thresholds []float64
+	// FeelsLike labels the feels-like temperature instead of the air
+	// temperature whenever it is known.
+	FeelsLike bool
+}
+
+// DefaultProfile is the built-in profile: cold below 60°F, hot from 85°F.
+func DefaultProfile() CategoryProfile {
+	return CategoryProfile{
+		Name:       DefaultCategoryProfile,
+		Labels:     []string{"cold", "moderate", "hot"},
+		Thresholds: []float64{60, 85},
+	}
+}
+
+// Categorize returns the label of the band tempF falls in.
+func (p CategoryProfile) Categorize(tempF float64) string {
+	i := 0
+	for i < len(p.Thresholds) && tempF >= p.Thresholds[i] {
+		i++
+	}
+	return p.Labels[i]
+}
+
+// Validate checks that the bands are well formed: a name of lower-case
+// letters, digits, '-' or '_', distinct labels and strictly increasing
+// thresholds, so that bands neither overlap nor are empty.
+func (p CategoryProfile) Validate() error {
+	if !profileNameRe.MatchString(p.Name) {
+		return fmt.Errorf("category profile %q: name must be 1-32 of a-z, 0-9, '-' and '_'", p.Name)
+	}
+	if len(p.Labels) == 0 {
+		return fmt.Errorf("category profile %q: no labels", p.Name)
+	}
+	if len(p.Thresholds) != len(p.Labels)-1 {
+		return fmt.Errorf("category profile %q: %d labels need %d thresholds, got %d",
+			p.Name, len(p.Labels), len(p.Labels)-1, len(p.Thresholds))
+	}
+	seen := make(map[string]bool, len(p.Labels))
+	for _, l := range p.Labels {
+		if l == "" || seen[l] {
+			return fmt.Errorf("category profile %q: labels must be distinct and not empty", p.Name)
+		}
+		seen[l] = true
+	}
+	for i, t := range p.Thresholds {
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return fmt.Errorf("category profile %q: threshold %v is not a finite number", p.Name, t)
+		}
+		if i > 0 && t <= p.Thresholds[i-1] {
+			return fmt.Errorf("category profile %q: thresholds must be strictly increasing, %v follows %v",
+				p.Name, t, p.Thresholds[i-1])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "errors"
+
+// Errors returned, wrapped, by the forecast provider. Adapters map them to
+// transport status codes.
+var (
+	// ErrNotFound: the provider has no data for the request, e.g. a point
+	// outside its coverage.
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited: the provider is throttling our requests.
+	ErrRateLimited = errors.New("rate limited by upstream")
+	// ErrUpstreamUnavailable: the provider is down or unreachable, or known
+	// to be down and the call was not attempted.
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	// ErrBadUpstreamData: the provider answered with something we cannot use.
+	ErrBadUpstreamData = errors.New("bad upstream response")
+)
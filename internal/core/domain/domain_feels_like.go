@@ -0,0 +1,45 @@
+package domain
+
+import "math"
+
+// FeelsLike is the apparent temperature NWS reports, in the unit of temp:
+// the heat index from 80°F when the relative humidity is known, the wind
+// chill up to 50°F with winds above 3 mph, and temp itself otherwise.
+func FeelsLike(temp Quantity, rhPct *float64, wind *Quantity) Quantity {
+	t := temp.In(Fahrenheit).Value
+	switch {
+	case t >= 80 && rhPct != nil:
+		return Quantity{Value: HeatIndexF(t, *rhPct), Unit: Fahrenheit}.In(temp.Unit)
+	case t <= 50 && wind != nil:
+		if v := wind.In(MilesPerHour).Value; v > 3 {
+			return Quantity{Value: WindChillF(t, v), Unit: Fahrenheit}.In(temp.Unit)
+		}
+	}
+	return temp
+}
+
+// HeatIndexF implements the NWS heat index: Steadman's simple formula, or
+// the Rothfusz regression with its low and high humidity adjustments once
+// that averages 80°F or more with the temperature.
+func HeatIndexF(t, rh float64) float64 {
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (hi+t)/2 < 80 {
+		return hi
+	}
+	hi = -42.379 + 2.04901523*t + 10.14333127*rh - 0.22475541*t*rh -
+		0.00683783*t*t - 0.05481717*rh*rh + 0.00122874*t*t*rh +
+		0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+	switch {
+	case rh < 13 && t >= 80 && t <= 112:
+		hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+	case rh > 85 && t >= 80 && t <= 87:
+		hi += (rh - 85) / 10 * (87 - t) / 5
+	}
+	return hi
+}
+
+// WindChillF implements the 2001 NWS wind chill formula.
+func WindChillF(t, windMph float64) float64 {
+	v := math.Pow(windMph, 0.16)
+	return 35.74 + 0.6215*t - 35.75*v + 0.4275*t*v
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFeelsLike(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	mph := func(v float64) *Quantity { return &Quantity{Value: v, Unit: MilesPerHour} }
+	// expected values from the NWS heat index and wind chill charts, ±1°F
+	tests := []struct {
+		name string
+		temp float64
+		rh   *float64
+		wind *Quantity
+		want float64
+	}{
+		{"humid day", 84, f(80), mph(5), 94},
+		{"hot and humid", 90, f(70), nil, 106},
+		{"dry heat", 100, f(10), nil, 94},
+		{"muggy", 84, f(95), nil, 101},
+		{"no humidity", 95, nil, mph(10), 95},
+		{"mild", 65, f(90), mph(20), 65},
+		{"windy cold", 0, nil, mph(15), -19},
+		{"breezy", 30, f(50), mph(10), 21},
+		{"calm cold", 30, nil, mph(2), 30},
+	}
+	for _, tc := range tests {
+		got := FeelsLike(Quantity{Value: tc.temp, Unit: Fahrenheit}, tc.rh, tc.wind)
+		if got.Unit != Fahrenheit || math.Abs(got.Value-tc.want) > 1 {
+			t.Errorf("%s: got %+v, want %.0f", tc.name, got, tc.want)
+		}
+	}
+
+	// 30°C at 70% is 86°F, a heat index of 95°F (35°C); -5°C with 20 km/h
+	// winds is 23°F and 12.4 mph, a wind chill of 11°F (-11.5°C)
+	if got := FeelsLike(Quantity{Value: 30, Unit: Celsius}, f(70), nil); got.Unit != Celsius || math.Abs(got.Value-35) > 0.5 {
+		t.Errorf("heat index in Celsius: got %+v", got)
+	}
+	if got := FeelsLike(Quantity{Value: -5, Unit: Celsius}, nil, &Quantity{Value: 20, Unit: KilometersPerHour}); got.Unit != Celsius || math.Abs(got.Value+11.5) > 0.5 {
+		t.Errorf("wind chill in Celsius: got %+v", got)
+	}
+}
+
+func TestUnitSystems(t *testing.T) {
+	q := Quantity{Value: 212, Unit: Fahrenheit}
+	for sys, want := range map[UnitSystem]Quantity{
+		US:     q,
+		SI:     {Value: 100, Unit: Celsius},
+		Metric: {Value: 100, Unit: Celsius},
+	} {
+		if got := sys.Convert(q); got.Unit != want.Unit || math.Abs(got.Value-want.Value) > 1e-9 {
+			t.Errorf("%s: got %+v, want %+v", sys, got, want)
+		}
+	}
+	wind := Quantity{Value: 36, Unit: KilometersPerHour}
+	if got := SI.Convert(wind); got.Unit != MetersPerSecond || math.Abs(got.Value-10) > 1e-9 {
+		t.Errorf("si wind: got %+v", got)
+	}
+	if got := US.Convert(Quantity{Value: 1609.344, Unit: Meters}); got.Unit != Miles || math.Abs(got.Value-1) > 1e-9 {
+		t.Errorf("us distance: got %+v", got)
+	}
+	if got := (Quantity{Value: 3, Unit: Celsius}).In(Miles); got.Unit != Celsius {
+		t.Errorf("want no conversion across dimensions, got %+v", got)
+	}
+	if _, err := ParseUnitSystem("imperial"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("want an invalid argument, got %v", err)
+	}
+	if u, err := ParseUnitSystem(" SI "); err != nil || u != SI {
+		t.Errorf("got %v, %v", u, err)
+	}
+}
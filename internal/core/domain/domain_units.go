@@ -0,0 +1,136 @@
+package domain
+
+import "strings"
+
+// Unit is the symbol of a unit of measure, as reported to clients.
+type Unit string
+
+const (
+	Fahrenheit Unit = "F"
+	Celsius    Unit = "C"
+
+	MilesPerHour      Unit = "mph"
+	KilometersPerHour Unit = "km/h"
+	MetersPerSecond   Unit = "m/s"
+
+	Miles      Unit = "mi"
+	Kilometers Unit = "km"
+	Meters     Unit = "m"
+
+	Millibars    Unit = "mb"
+	Hectopascals Unit = "hPa"
+	Pascals      Unit = "Pa"
+)
+
+type dimension int
+
+const (
+	temperature dimension = iota + 1
+	speed
+	distance
+	pressure
+)
+
+// units maps each unit to its dimension and to the base unit of that
+// dimension (C, m/s, m, Pa): base = value*scale + offset.
+var units = map[Unit]struct {
+	dim           dimension
+	scale, offset float64
+}{
+	Celsius:           {temperature, 1, 0},
+	Fahrenheit:        {temperature, 5.0 / 9, -32 * 5.0 / 9},
+	MetersPerSecond:   {speed, 1, 0},
+	KilometersPerHour: {speed, 1 / 3.6, 0},
+	MilesPerHour:      {speed, 0.44704, 0},
+	Meters:            {distance, 1, 0},
+	Kilometers:        {distance, 1000, 0},
+	Miles:             {distance, 1609.344, 0},
+	Pascals:           {pressure, 1, 0},
+	Hectopascals:      {pressure, 100, 0},
+	Millibars:         {pressure, 100, 0},
+}
+
+// Quantity is a measurement tagged with its unit.
+type Quantity struct {
+	Value float64
+	Unit  Unit
+}
+
+// In converts q to u. A quantity with an unknown unit, or one of another
+// dimension than u, is returned unchanged.
+func (q Quantity) In(u Unit) Quantity {
+	from, ok1 := units[q.Unit]
+	to, ok2 := units[u]
+	if q.Unit == u || !ok1 || !ok2 || from.dim != to.dim {
+		return q
+	}
+	return Quantity{Value: (q.Value*from.scale + from.offset - to.offset) / to.scale, Unit: u}
+}
+
+// UnitSystem selects the units quantities are reported in.
+type UnitSystem string
+
+const (
+	// US reports °F, mph, miles and millibars, the units NWS forecasts in.
+	US UnitSystem = "us"
+	// SI reports °C, m/s, km and hPa.
+	SI UnitSystem = "si"
+	// Metric reports °C, km/h, km and hPa.
+	Metric UnitSystem = "metric"
+)
+
+// ParseUnitSystem reads a unit system name; an empty one is US.
+func ParseUnitSystem(s string) (UnitSystem, error) {
+	switch u := UnitSystem(strings.ToLower(strings.TrimSpace(s))); u {
+	case "":
+		return US, nil
+	case US, SI, Metric:
+		return u, nil
+	}
+	var verr ValidationError
+	verr.add("units", "must be one of us, si or metric")
+	return "", verr.err()
+}
+
+// Convert returns q in the unit the system uses for its dimension.
+func (s UnitSystem) Convert(q Quantity) Quantity {
+	switch units[q.Unit].dim {
+	case temperature:
+		return q.In(s.pick(Fahrenheit, Celsius, Celsius))
+	case speed:
+		return q.In(s.pick(MilesPerHour, MetersPerSecond, KilometersPerHour))
+	case distance:
+		return q.In(s.pick(Miles, Kilometers, Kilometers))
+	case pressure:
+		return q.In(s.pick(Millibars, Hectopascals, Hectopascals))
+	}
+	return q
+}
+
+// ConvertPtr is Convert for optional quantities.
+func (s UnitSystem) ConvertPtr(q *Quantity) *Quantity {
+	if q == nil {
+		return nil
+	}
+	c := s.Convert(*q)
+	return &c
+}
+
+func (s UnitSystem) pick(us, si, metric Unit) Unit {
+	switch s {
+	case SI:
+		return si
+	case Metric:
+		return metric
+	}
+	return us
+}
+
+// ValueIn returns the value of an optional quantity in u, nil if q is nil.
+func ValueIn(q *Quantity, u Unit) *float64 {
+	if q == nil {
+		return nil
+	}
+	v := q.In(u).Value
+	return &v
+}
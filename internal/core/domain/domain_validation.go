@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CoordinatePrecision is the number of decimals coordinates are rounded to;
+// NWS does not accept more and ~11 m is finer than any forecast grid.
+const CoordinatePrecision = 4
+
+// maxCoordinateLen bounds coordinate strings before they are parsed.
+const maxCoordinateLen = 32
+
+// ErrInvalidArgument is matched, with errors.Is, by every ValidationError.
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// FieldViolation describes why one request field is invalid. Description
+// is Format applied to Args; translations are looked up by Format.
+type FieldViolation struct {
+	Field       string
+	Description string
+	Format      string
+	Args        []any
+}
+
+// Violation builds a FieldViolation from a printf format.
+func Violation(field, format string, args ...any) FieldViolation {
+	return FieldViolation{Field: field, Description: fmt.Sprintf(format, args...), Format: format, Args: args}
+}
+
+// ValidationError lists the invalid fields of a request.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		parts = append(parts, v.Field+" "+v.Description)
+	}
+	return "invalid " + strings.Join(parts, ", ")
+}
+
+func (e *ValidationError) Is(target error) bool { return target == ErrInvalidArgument }
+
+func (e *ValidationError) add(field, format string, args ...any) {
+	e.Violations = append(e.Violations, Violation(field, format, args...))
+}
+
+func (e *ValidationError) err() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
+
+// BoundingBox is a latitude/longitude rectangle. A box with MinLon > MaxLon
+// crosses the antimeridian.
+type BoundingBox struct {
+	MinLat, MaxLat float64
+	MinLon, MaxLon float64
+}
+
+func (b BoundingBox) Contains(lat, lon float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.MinLon <= b.MaxLon {
+		return lon >= b.MinLon && lon <= b.MaxLon
+	}
+	return lon >= b.MinLon || lon <= b.MaxLon
+}
+
+// Coverage is the area a forecast provider serves; an empty Coverage
+// accepts any point.
+type Coverage []BoundingBox
+
+func (c Coverage) Contains(lat, lon float64) bool {
+	if len(c) == 0 {
+		return true
+	}
+	for _, b := range c {
+		if b.Contains(lat, lon) {
+			return true
+		}
+	}
+	return false
+}
+
+// NWSCoverage roughly bounds the areas NWS forecasts for: the contiguous US,
+// Alaska, Hawaii, Puerto Rico and the US Virgin Islands, Guam and the
+// Northern Marianas, and American Samoa.
+var NWSCoverage = Coverage{
+	{MinLat: 24, MaxLat: 50, MinLon: -125, MaxLon: -66},
+	{MinLat: 51, MaxLat: 72, MinLon: 172, MaxLon: -129},
+	{MinLat: 18, MaxLat: 23, MinLon: -161, MaxLon: -154},
+	{MinLat: 17, MaxLat: 19, MinLon: -68, MaxLon: -64},
+	{MinLat: 13, MaxLat: 21, MinLon: 144, MaxLon: 146},
+	{MinLat: -15, MaxLat: -11, MinLon: -172, MaxLon: -168},
+}
+
+// NormalizeLatLon validates a point and returns it with the longitude
+// wrapped into [-180, 180) and both rounded to CoordinatePrecision decimals.
+// Longitudes up to one turn past the antimeridian are wrapped; anything
+// further out is rejected as garbage. The point must lie in coverage.
+func NormalizeLatLon(lat, lon float64, coverage Coverage) (float64, float64, error) {
+	var verr ValidationError
+	switch {
+	case math.IsNaN(lat) || math.IsInf(lat, 0):
+		verr.add("lat", "must be a finite number")
+	case lat < -90 || lat > 90:
+		verr.add("lat", "must be between -90 and 90")
+	}
+	switch {
+	case math.IsNaN(lon) || math.IsInf(lon, 0):
+		verr.add("lon", "must be a finite number")
+	case lon < -360 || lon > 360:
+		verr.add("lon", "must be between -360 and 360")
+	}
+	if err := verr.err(); err != nil {
+		return 0, 0, err
+	}
+
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	lat, lon = roundCoordinate(lat), roundCoordinate(lon-180)
+	if lon == 180 {
+		lon = -180
+	}
+	if !coverage.Contains(lat, lon) {
+		verr.add("lat", "is outside the forecast coverage")
+		verr.add("lon", "is outside the forecast coverage")
+		return 0, 0, &verr
+	}
+	return lat, lon, nil
+}
+
+func roundCoordinate(x float64) float64 {
+	p := math.Pow10(CoordinatePrecision)
+	// +0 turns -0 into 0 so that equal points share a cache key
+	return math.Round(x*p)/p + 0
+}
+
+// ParseLatLon parses and normalizes a point given as decimal strings, as in
+// query parameters.
+func ParseLatLon(latStr, lonStr string, coverage Coverage) (float64, float64, error) {
+	var verr ValidationError
+	lat, ok := parseCoordinate(&verr, "lat", latStr)
+	lon, ok2 := parseCoordinate(&verr, "lon", lonStr)
+	if !ok || !ok2 {
+		return 0, 0, &verr
+	}
+	return NormalizeLatLon(lat, lon, coverage)
+}
+
+func parseCoordinate(verr *ValidationError, field, s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		verr.add(field, "is required")
+		return 0, false
+	case len(s) > maxCoordinateLen:
+		verr.add(field, "must be at most %d characters", maxCoordinateLen)
+		return 0, false
+	}
+	x, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		verr.add(field, "must be a decimal number")
+		return 0, false
+	}
+	return x, true
+}
+
+// NWS public and forecast zones, e.g. MDZ011 or MDC031
+var zoneRe = regexp.MustCompile(`^[A-Z]{2}[CZ][0-9]{3}$`)
+
+// NormalizeAlertQuery validates q, upper-casing its zone or normalizing its
+// point when no zone is given.
+func NormalizeAlertQuery(q AlertQuery, coverage Coverage) (AlertQuery, error) {
+	if q.Zone != "" {
+		q.Zone = strings.ToUpper(strings.TrimSpace(q.Zone))
+		if !zoneRe.MatchString(q.Zone) {
+			return AlertQuery{}, &ValidationError{Violations: []FieldViolation{Violation("zone", "must be an NWS zone such as MDZ011")}}
+		}
+		q.Lat, q.Lon = 0, 0
+		return q, nil
+	}
+	lat, lon, err := NormalizeLatLon(q.Lat, q.Lon, coverage)
+	if err != nil {
+		return AlertQuery{}, err
+	}
+	q.Lat, q.Lon = lat, lon
+	return q, nil
+}
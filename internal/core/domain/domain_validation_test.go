@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNormalizeLatLon(t *testing.T) {
+	cases := []struct {
+		name             string
+		lat, lon         float64
+		coverage         Coverage
+		wantLat, wantLon float64
+		wantFields       []string
+	}{
+		{"valid", 38.88944444, -77.03527777, nil, 38.8894, -77.0353, nil},
+		{"wraps east longitude", 38.8894, 282.9647, nil, 38.8894, -77.0353, nil},
+		{"antimeridian", 0, 180, nil, 0, -180, nil},
+		{"negative zero", -0.00001, -0.00001, nil, 0, 0, nil},
+		{"NaN", math.NaN(), 1, nil, 0, 0, []string{"lat"}},
+		{"Inf", 1, math.Inf(-1), nil, 0, 0, []string{"lon"}},
+		{"out of range", 500, -999, nil, 0, 0, []string{"lat", "lon"}},
+		{"inside coverage", 38.8894, -77.0352, NWSCoverage, 38.8894, -77.0352, nil},
+		{"Aleutians across the antimeridian", 52.9, 173.2, NWSCoverage, 52.9, 173.2, nil},
+		{"outside coverage", 51.5072, -0.1276, NWSCoverage, 0, 0, []string{"lat", "lon"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lat, lon, err := NormalizeLatLon(tc.lat, tc.lon, tc.coverage)
+			if tc.wantFields == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if lat != tc.wantLat || lon != tc.wantLon || math.Signbit(lat) || math.Signbit(lon) != (tc.wantLon < 0) {
+					t.Fatalf("want %v,%v got %v,%v", tc.wantLat, tc.wantLon, lat, lon)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) || !errors.Is(err, ErrInvalidArgument) {
+				t.Fatalf("want a validation error, got %v", err)
+			}
+			if len(verr.Violations) != len(tc.wantFields) {
+				t.Fatalf("want violations of %v, got %+v", tc.wantFields, verr.Violations)
+			}
+			for i, f := range tc.wantFields {
+				if verr.Violations[i].Field != f {
+					t.Fatalf("want violations of %v, got %+v", tc.wantFields, verr.Violations)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeAlertQuery(t *testing.T) {
+	q, err := NormalizeAlertQuery(AlertQuery{Zone: " mdz011", Lat: 1}, nil)
+	if err != nil || q != (AlertQuery{Zone: "MDZ011"}) {
+		t.Fatalf("got %+v %v", q, err)
+	}
+	if _, err := NormalizeAlertQuery(AlertQuery{Zone: "MD/../Z011"}, nil); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("want an invalid zone, got %v", err)
+	}
+}
+
+func FuzzParseLatLon(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"38.8894", "-77.0352"}, {"NaN", "1"}, {"1e308", "-1e308"}, {"-0", "180"},
+		{"", " "}, {"0x1p-2", "1_0"}, {"90.00005", "359.99999"}, {"+Inf", "-360"},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+	f.Fuzz(func(t *testing.T, latStr, lonStr string) {
+		lat, lon, err := ParseLatLon(latStr, lonStr, nil)
+		if err != nil {
+			var verr *ValidationError
+			if !errors.As(err, &verr) || len(verr.Violations) == 0 {
+				t.Fatalf("want field violations, got %v", err)
+			}
+			return
+		}
+		if lat < -90 || lat > 90 || lon < -180 || lon >= 180 || math.IsNaN(lat) || math.IsNaN(lon) {
+			t.Fatalf("%q,%q normalized out of range: %v,%v", latStr, lonStr, lat, lon)
+		}
+		if lat2, lon2, err := NormalizeLatLon(lat, lon, nil); err != nil || lat2 != lat || lon2 != lon {
+			t.Fatalf("normalizing %v,%v again gave %v,%v %v", lat, lon, lat2, lon2, err)
+		}
+	})
+}
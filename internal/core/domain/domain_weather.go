@@ -1,7 +1,119 @@
 package domain
 
+import "time"
+
+const (
+	// MaxForecastDays is the horizon of the NWS 7-day forecast.
+	MaxForecastDays = 7
+	// MaxForecastHours is the horizon of the NWS hourly forecast.
+	MaxForecastHours = 156
+	// DefaultForecastHours is used when the caller does not ask for a horizon.
+	DefaultForecastHours = 24
+)
+
+// Location is a named coordinate, e.g. one of the sites whose forecast is
+// kept warm in the cache.
+type Location struct {
+	Name string
+	Lat  float64
+	Lon  float64
+}
+
 type TodayForecast struct {
 	ShortForecast string
-	TemperatureF  float64
-	Category      string
+	Temperature   Quantity
+	// FeelsLike is the heat index or wind chill of Temperature, see
+	// FeelsLike; Temperature itself when neither applies.
+	FeelsLike Quantity
+	// High is today's daytime high and Low tonight's low, in the local time
+	// zone of the location; nil once past or not forecast.
+	High     *Quantity
+	Low      *Quantity
+	Category string
+	// Stale is set when the forecast is served from cache past its TTL.
+	Stale bool
+	// Expires is when the upstream forecast stops being fresh; zero if the
+	// provider did not say.
+	Expires time.Time
+}
+
+// ForecastPeriod is one day or night period of the multi-day forecast.
+type ForecastPeriod struct {
+	Name             string
+	StartTime        time.Time
+	EndTime          time.Time
+	IsDaytime        bool
+	Temperature      Quantity
+	ShortForecast    string
+	DetailedForecast string
+}
+
+// Forecast is the multi-day forecast of a location.
+type Forecast struct {
+	Periods []ForecastPeriod
+	// Expires is when the upstream forecast stops being fresh; zero if the
+	// provider did not say.
+	Expires time.Time
+}
+
+// HourlyForecast is the hourly forecast of a location.
+type HourlyForecast struct {
+	Hours []HourlyPeriod
+	// Expires is when the upstream forecast stops being fresh; zero if the
+	// provider did not say.
+	Expires time.Time
+}
+
+// HourlyPeriod is one hour of the NWS hourly forecast.
+type HourlyPeriod struct {
+	StartTime                time.Time
+	EndTime                  time.Time
+	Temperature              Quantity
+	WindSpeed                Quantity
+	WindDirection            string
+	PrecipitationProbability float64 // percent
+	ShortForecast            string
+}
+
+// AlertQuery selects active alerts by forecast zone (e.g. "MDZ011") or, when
+// Zone is empty, by point.
+type AlertQuery struct {
+	Lat  float64
+	Lon  float64
+	Zone string
+}
+
+// Alert is an NWS watch, warning or advisory.
+type Alert struct {
+	ID            string
+	Event         string
+	Severity      string
+	Urgency       string
+	Certainty     string
+	Onset         time.Time
+	Expires       time.Time
+	Headline      string
+	Description   string
+	Instruction   string
+	AreaDesc      string
+	AffectedZones []string
+}
+
+// CurrentConditions is the latest observation of the station nearest to a
+// point. Measurements the station did not report are nil.
+type CurrentConditions struct {
+	StationID        string
+	StationName      string
+	StationDistance  Quantity
+	ObservedAt       time.Time
+	TextDescription  string
+	Temperature      *Quantity
+	FeelsLike        *Quantity
+	Dewpoint         *Quantity
+	RelativeHumidity *float64 // percent
+	WindSpeed        *Quantity
+	WindDirectionDeg *float64
+	Pressure         *Quantity
+	Visibility       *Quantity
+	Category         string
 }
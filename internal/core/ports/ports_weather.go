@@ -7,9 +7,20 @@ import (
 )
 
 type NWSClient interface {
-	GetToday(ctx context.Context, lat, lon float64) (string, float64, error)
+	GetToday(ctx context.Context, lat, lon float64) (domain.TodayForecast, error)
+	GetForecast(ctx context.Context, lat, lon float64, days int) (domain.Forecast, error)
+	GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (domain.HourlyForecast, error)
+	GetActiveAlerts(ctx context.Context, q domain.AlertQuery) ([]domain.Alert, error)
+	GetCurrentConditions(ctx context.Context, lat, lon float64) (domain.CurrentConditions, error)
 }
 
+// WeatherService categorizes temperatures with the named category profile,
+// or the default one when profile is empty.
 type WeatherService interface {
-	GetTodayForecast(ctx context.Context, lat, lon float64) (domain.TodayForecast, error)
+	GetTodayForecast(ctx context.Context, lat, lon float64, profile string) (domain.TodayForecast, error)
+	GetForecast(ctx context.Context, lat, lon float64, days int) ([]domain.ForecastPeriod, error)
+	GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) ([]domain.HourlyPeriod, error)
+	ListAlerts(ctx context.Context, q domain.AlertQuery) ([]domain.Alert, error)
+	GetCurrentConditions(ctx context.Context, lat, lon float64, profile string) (domain.CurrentConditions, error)
+	CategoryProfiles() []domain.CategoryProfile
 }
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+)
+
+// WithCategoryProfiles makes profiles selectable by name on top of the
+// built-in domain.DefaultProfile, which a profile named "default" replaces.
+// Profiles must be valid, as returned by ParseCategoryProfiles.
+func WithCategoryProfiles(profiles ...domain.CategoryProfile) Option {
+	return func(o *options) { o.profiles = append(o.profiles, profiles...) }
+}
+
+func (s *weatherService) CategoryProfiles() []domain.CategoryProfile {
+	out := make([]domain.CategoryProfile, 0, len(s.profiles))
+	for _, p := range s.profiles {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// profile looks a profile up by name; an empty name is the default profile.
+func (s *weatherService) profile(name string) (domain.CategoryProfile, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = domain.DefaultCategoryProfile
+	}
+	p, ok := s.profiles[name]
+	if !ok {
+		return domain.CategoryProfile{}, &domain.ValidationError{Violations: []domain.FieldViolation{
+			domain.Violation("profile", "is not a known category profile: %q", name),
+		}}
+	}
+	return p, nil
+}
+
+// categorize labels the temperature the profile is based on; feelsLike is
+// nil when unknown.
+func categorize(p domain.CategoryProfile, air domain.Quantity, feelsLike *domain.Quantity) string {
+	if p.FeelsLike && feelsLike != nil {
+		air = *feelsLike
+	}
+	return p.Categorize(air.In(domain.Fahrenheit).Value)
+}
+
+// ParseCategoryProfiles reads one profile per line as its name, a colon and
+// its labels from coldest to hottest separated by the °F thresholds between
+// them, e.g. "phoenix: cold 70 moderate 95 hot 110 scorching". A name
+// followed by "feels-like" labels the feels-like temperature, as in
+// "safety feels-like: ok 91 caution 103 danger". Blank lines and lines
+// starting with '#' are skipped. Every profile is validated and
+// names must be unique.
+func ParseCategoryProfiles(r io.Reader) ([]domain.CategoryProfile, error) {
+	var profiles []domain.CategoryProfile
+	seen := map[string]bool{}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p, err := parseCategoryProfile(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("line %d: category profile %q defined twice", n, p.Name)
+		}
+		seen[p.Name] = true
+		profiles = append(profiles, p)
+	}
+	return profiles, sc.Err()
+}
+
+func parseCategoryProfile(s string) (domain.CategoryProfile, error) {
+	name, bands, ok := strings.Cut(s, ":")
+	if !ok {
+		return domain.CategoryProfile{}, fmt.Errorf("want name: label threshold label ..., got %q", s)
+	}
+	p := domain.CategoryProfile{Name: strings.TrimSpace(name)}
+	if f := strings.Fields(name); len(f) == 2 && f[1] == "feels-like" {
+		p.Name, p.FeelsLike = f[0], true
+	}
+	for i, f := range strings.Fields(bands) {
+		if i%2 == 0 {
+			p.Labels = append(p.Labels, f)
+			continue
+		}
+		t, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return domain.CategoryProfile{}, fmt.Errorf("category profile %q: threshold %q is not a number", p.Name, f)
+		}
+		p.Thresholds = append(p.Thresholds, t)
+	}
+	return p, p.Validate()
+}
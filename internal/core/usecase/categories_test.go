@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+)
+
+func TestDefaultProfile(t *testing.T) {
+	p := domain.DefaultProfile()
+	if p.Categorize(85) != "hot" || p.Categorize(60) != "moderate" || p.Categorize(59.9) != "cold" {
+		t.Fatal("default thresholds changed")
+	}
+}
+
+func TestParseCategoryProfiles(t *testing.T) {
+	in := `# Phoenix and Anchorage
+phoenix: cold 70 moderate 95 hot 110 scorching
+
+anchorage: freezing 32 cold 50 moderate 65 hot
+flat: mild
+safety feels-like: ok 91 caution 103 danger
+`
+	profiles, err := ParseCategoryProfiles(strings.NewReader(in))
+	if err != nil || len(profiles) != 4 || profiles[0].FeelsLike || !profiles[3].FeelsLike || profiles[3].Name != "safety" {
+		t.Fatalf("got %+v, %v", profiles, err)
+	}
+	phx := profiles[0]
+	for temp, want := range map[float64]string{-10: "cold", 70: "moderate", 109.9: "hot", 110: "scorching"} {
+		if got := phx.Categorize(temp); got != want {
+			t.Errorf("phoenix %v°F: got %s, want %s", temp, got, want)
+		}
+	}
+	if profiles[2].Categorize(200) != "mild" {
+		t.Error("a single label takes every temperature")
+	}
+
+	for _, bad := range []string{
+		"phoenix cold 70 hot",
+		"phoenix: cold 95 moderate 70 hot",
+		"phoenix: cold 70 moderate 70 hot",
+		"phoenix: cold 70 cold",
+		"phoenix: cold seventy hot",
+		"phoenix: cold 70",
+		"phoenix:",
+		"Phoenix AZ: cold 70 hot",
+		"safety feels like: cold 70 hot",
+		"a: x\na: y",
+	} {
+		if _, err := ParseCategoryProfiles(strings.NewReader(bad)); err == nil {
+			t.Errorf("%q: want an error", bad)
+		}
+	}
+}
+
+func TestService_CategoryProfiles(t *testing.T) {
+	phx := domain.CategoryProfile{Name: "phoenix", Labels: []string{"cold", "moderate", "hot"}, Thresholds: []float64{70, 95}}
+	svc := NewWeatherService(fakeNWS{temp: 90}, nil, WithCategoryProfiles(phx))
+	ctx := context.Background()
+
+	def, err := svc.GetTodayForecast(ctx, 1, 2, "")
+	if err != nil || def.Category != "hot" {
+		t.Fatalf("default profile: %+v %v", def, err)
+	}
+	got, err := svc.GetTodayForecast(ctx, 1, 2, "Phoenix")
+	if err != nil || got.Category != "moderate" {
+		t.Fatalf("phoenix profile: %+v %v", got, err)
+	}
+	var verr *domain.ValidationError
+	if _, err := svc.GetTodayForecast(ctx, 1, 2, "mars"); !errors.As(err, &verr) || verr.Violations[0].Field != "profile" {
+		t.Fatalf("want a profile violation, got %v", err)
+	}
+	if ps := svc.CategoryProfiles(); len(ps) != 2 || ps[0].Name != "default" || ps[1].Name != "phoenix" {
+		t.Fatalf("profiles %+v", ps)
+	}
+}
+
+func TestService_FeelsLikeProfile(t *testing.T) {
+	safety := domain.CategoryProfile{Name: "safety", Labels: []string{"ok", "caution"}, Thresholds: []float64{91}, FeelsLike: true}
+	svc := NewWeatherService(feelsLikeNWS{fakeNWS{temp: 84}}, nil, WithCategoryProfiles(safety))
+	ctx := context.Background()
+
+	got, err := svc.GetTodayForecast(ctx, 1, 2, "safety")
+	if err != nil || got.FeelsLike != degF(94) || got.Category != "caution" {
+		t.Fatalf("want caution from the heat index, got %+v %v", got, err)
+	}
+	if got, _ := svc.GetTodayForecast(ctx, 1, 2, ""); got.Category != "moderate" {
+		t.Fatalf("the default profile labels the air temperature, got %s", got.Category)
+	}
+}
+
+type feelsLikeNWS struct{ fakeNWS }
+
+func (f feelsLikeNWS) GetToday(ctx context.Context, lat, lon float64) (domain.TodayForecast, error) {
+	return domain.TodayForecast{Temperature: degF(f.temp), FeelsLike: degF(94)}, nil
+}
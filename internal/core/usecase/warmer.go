@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+	obs "github.com/rcglezreyes/go_weather/observability/metrics"
+)
+
+// ErrWarming is reported by WarmerStatus.Err until a pass over every
+// location has warmed at least one of them.
+var ErrWarming = errors.New("cache warmer: initial pass in progress")
+
+type WarmerConfig struct {
+	Locations []domain.Location
+	// Interval between refreshes of a location; keep it below the cache TTL
+	// so entries are replaced before they expire.
+	Interval time.Duration
+	// Concurrency caps the refreshes in flight (default 4).
+	Concurrency int
+	// A failing location is retried after BackoffBase, doubling on every
+	// failure up to BackoffMax (defaults 5s and Interval).
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+// WarmerStatus is a point-in-time view of the warmer's progress.
+type WarmerStatus struct {
+	Locations int    `json:"locations"`
+	Warmed    int    `json:"warmed"`
+	Failing   int    `json:"failing"`
+	Ready     bool   `json:"ready"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+func (s WarmerStatus) Err() error {
+	if !s.Ready {
+		return ErrWarming
+	}
+	return nil
+}
+
+// Warmable reloads the cached forecasts of a location.
+type Warmable interface {
+	Warm(ctx context.Context, lat, lon float64) error
+}
+
+// Warmer keeps the forecasts of a fixed set of locations in the cache so
+// that requests for them never wait on NWS.
+type Warmer struct {
+	svc Warmable
+	cfg WarmerConfig
+
+	mu      sync.Mutex
+	state   []warmState
+	ready   bool
+	lastErr string
+}
+
+type warmState struct {
+	next     time.Time
+	failures int
+	warmed   bool
+}
+
+// NewWarmer returns a warmer refreshing svc, such as the Service returned
+// by NewWeatherService.
+func NewWarmer(svc Warmable, cfg WarmerConfig) (*Warmer, error) {
+	if cfg.Interval <= 0 {
+		return nil, errors.New("cache warmer: interval must be positive")
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	if cfg.BackoffBase <= 0 {
+		cfg.BackoffBase = 5 * time.Second
+	}
+	if cfg.BackoffMax <= 0 {
+		cfg.BackoffMax = cfg.Interval
+	}
+	obs.WarmerLocations.Set(float64(len(cfg.Locations)))
+	return &Warmer{svc: svc, cfg: cfg, state: make([]warmState, len(cfg.Locations))}, nil
+}
+
+// Run warms every location right away and then keeps refreshing them until
+// ctx is done.
+func (w *Warmer) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		timer.Reset(time.Until(w.pass(ctx)))
+	}
+}
+
+// pass refreshes the locations that are due and returns when the next one is.
+func (w *Warmer) pass(ctx context.Context) time.Time {
+	now := time.Now()
+	var g errgroup.Group
+	g.SetLimit(w.cfg.Concurrency)
+	for i, loc := range w.cfg.Locations {
+		w.mu.Lock()
+		due := !w.state[i].next.After(now)
+		w.mu.Unlock()
+		if !due {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			err := w.svc.Warm(ctx, loc.Lat, loc.Lon)
+			w.record(i, loc, err)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	next := now.Add(w.cfg.Interval)
+	warmed := 0
+	for _, st := range w.state {
+		if st.next.Before(next) {
+			next = st.next
+		}
+		if st.warmed {
+			warmed++
+		}
+	}
+	// a pass over every location that warmed none of them, as when NWS is
+	// down at startup, does not make the warmer ready
+	w.ready = w.ready || ctx.Err() == nil && (warmed > 0 || len(w.state) == 0)
+	obs.WarmerWarmed.Set(float64(warmed))
+	return next
+}
+
+func (w *Warmer) record(i int, loc domain.Location, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	st := &w.state[i]
+	if err != nil {
+		obs.WarmerRefreshesTotal.WithLabelValues("error").Inc()
+		st.failures++
+		st.warmed = false
+		st.next = time.Now().Add(w.backoff(st.failures))
+		w.lastErr = fmt.Sprintf("%s: %v", loc.Name, err)
+		return
+	}
+	obs.WarmerRefreshesTotal.WithLabelValues("ok").Inc()
+	st.failures = 0
+	st.warmed = true
+	st.next = time.Now().Add(w.cfg.Interval)
+}
+
+// backoff doubles per failure, capped at BackoffMax, with up to 10% jitter so
+// failing locations do not retry in lockstep.
+func (w *Warmer) backoff(failures int) time.Duration {
+	d := w.cfg.BackoffMax
+	if failures < 32 {
+		d = min(w.cfg.BackoffBase<<(failures-1), w.cfg.BackoffMax)
+	}
+	return d + rand.N(d/10+1)
+}
+
+func (w *Warmer) Status() WarmerStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	s := WarmerStatus{Locations: len(w.state), Ready: w.ready, LastError: w.lastErr}
+	for _, st := range w.state {
+		if st.warmed {
+			s.Warmed++
+		}
+		if st.failures > 0 {
+			s.Failing++
+		}
+	}
+	return s
+}
+
+// ParseLocations reads one "name,lat,lon" location per line. Blank lines and
+// lines starting with # are skipped.
+func ParseLocations(r io.Reader) ([]domain.Location, error) {
+	var locs []domain.Location
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		loc, err := parseLocation(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		locs = append(locs, loc)
+	}
+	return locs, sc.Err()
+}
+
+func parseLocation(s string) (domain.Location, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return domain.Location{}, fmt.Errorf("want name,lat,lon, got %q", s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return domain.Location{}, fmt.Errorf("invalid latitude in %q", s)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return domain.Location{}, fmt.Errorf("invalid longitude in %q", s)
+	}
+	return domain.Location{Name: strings.TrimSpace(parts[0]), Lat: lat, Lon: lon}, nil
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
+	"github.com/rcglezreyes/go_weather/internal/pkg/cache"
+)
+
+// flakyNWS fails the first failures GetToday calls.
+type flakyNWS struct {
+	fakeNWS
+	failures int64
+	calls    atomic.Int64
+}
+
+func (f *flakyNWS) GetToday(ctx context.Context, lat, lon float64) (domain.TodayForecast, error) {
+	if f.calls.Add(1) <= f.failures {
+		return domain.TodayForecast{}, errors.New("nws down")
+	}
+	return domain.TodayForecast{ShortForecast: "Sunny", Temperature: degF(70)}, nil
+}
+
+func TestWarmer_PrepopulatesAndRecoversFromErrors(t *testing.T) {
+	c := cache.NewTTLCache(cache.Config{TTL: 60, MaxEntries: 100})
+	nws := &flakyNWS{failures: 1}
+	svc := NewWeatherService(nws, c)
+	locs := []domain.Location{{Name: "a", Lat: 1, Lon: 2}, {Name: "b", Lat: 3, Lon: 4}}
+	w, err := NewWarmer(svc, WarmerConfig{Locations: locs, Interval: time.Hour, Concurrency: 1, BackoffBase: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Status().Err(); !errors.Is(err, ErrWarming) {
+		t.Fatalf("want ErrWarming before the first pass, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for w.Status().Warmed < len(locs) {
+		if time.Now().After(deadline) {
+			t.Fatalf("locations not warmed: %+v", w.Status())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	st := w.Status()
+	if !st.Ready || st.Failing != 0 || !strings.HasPrefix(st.LastError, "a: ") {
+		t.Fatalf("unexpected status %+v", st)
+	}
+	if nws.calls.Load() != 3 {
+		t.Fatalf("want the failed location retried once, got %d calls", nws.calls.Load())
+	}
+
+	// requests for warm locations are served from cache
+	if _, err := svc.GetTodayForecast(context.Background(), 3, 4, ""); err != nil {
+		t.Fatal(err)
+	}
+	if nws.calls.Load() != 3 {
+		t.Fatal("expected a cache hit for a warmed location")
+	}
+}
+
+// warmFunc lets any function be warmed, as decorators of the service are.
+type warmFunc func(ctx context.Context, lat, lon float64) error
+
+func (f warmFunc) Warm(ctx context.Context, lat, lon float64) error { return f(ctx, lat, lon) }
+
+func TestWarmer_NotReadyUntilAWarmUpSucceeds(t *testing.T) {
+	var down atomic.Bool
+	down.Store(true)
+	svc := warmFunc(func(ctx context.Context, lat, lon float64) error {
+		if down.Load() {
+			return errors.New("nws down")
+		}
+		return nil
+	})
+	locs := []domain.Location{{Name: "a", Lat: 1, Lon: 2}, {Name: "b", Lat: 3, Lon: 4}}
+	w, err := NewWarmer(svc, WarmerConfig{Locations: locs, Interval: time.Hour, BackoffBase: time.Nanosecond, BackoffMax: time.Nanosecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w.pass(context.Background())
+	if st := w.Status(); st.Ready || st.Failing != 2 || !errors.Is(st.Err(), ErrWarming) {
+		t.Fatalf("want not ready after a pass where every location failed, got %+v", st)
+	}
+
+	down.Store(false)
+	time.Sleep(time.Millisecond)
+	w.pass(context.Background())
+	if st := w.Status(); !st.Ready || st.Warmed != 2 {
+		t.Fatalf("want ready once locations are warm, got %+v", st)
+	}
+}
+
+func TestWarmer_Backoff(t *testing.T) {
+	w := &Warmer{cfg: WarmerConfig{BackoffBase: time.Second, BackoffMax: 10 * time.Second}}
+	for _, tc := range []struct {
+		failures int
+		want     time.Duration
+	}{{1, time.Second}, {2, 2 * time.Second}, {4, 8 * time.Second}, {5, 10 * time.Second}, {100, 10 * time.Second}} {
+		got := w.backoff(tc.failures)
+		if got < tc.want || got > tc.want+tc.want/10 {
+			t.Errorf("backoff(%d) = %v, want %v plus jitter", tc.failures, got, tc.want)
+		}
+	}
+}
+
+func TestParseLocations(t *testing.T) {
+	locs, err := ParseLocations(strings.NewReader("# sites\nhq, 38.8894, -77.0352\n\nlab,40.7,-74\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locs) != 2 || locs[0] != (domain.Location{Name: "hq", Lat: 38.8894, Lon: -77.0352}) {
+		t.Fatalf("unexpected locations %+v", locs)
+	}
+	for _, bad := range []string{"hq,38.8", "hq,91,0", "hq,0,abc"} {
+		if _, err := ParseLocations(strings.NewReader(bad)); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+}
@@ -4,46 +4,247 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
+	"time"
 
 	"github.com/rcglezreyes/go_weather/internal/core/domain"
 	"github.com/rcglezreyes/go_weather/internal/core/ports"
 	"github.com/rcglezreyes/go_weather/internal/pkg/cache"
+	obs "github.com/rcglezreyes/go_weather/observability/metrics"
 )
 
 type weatherService struct {
-	nws   ports.NWSClient
-	cache cache.KV
+	nws ports.NWSClient
+
+	today      *cache.Typed[domain.TodayForecast]
+	daily      *cache.Typed[[]domain.ForecastPeriod]
+	hourly     *cache.Typed[[]domain.HourlyPeriod]
+	conditions *cache.Typed[domain.CurrentConditions]
+	alerts     *cache.Typed[[]domain.Alert]
+
+	refreshing sync.Map // keys with a background refresh in flight
+
+	coverage domain.Coverage
+	profiles map[string]domain.CategoryProfile
 }
 
-func NewWeatherService(nws ports.NWSClient, c cache.KV) ports.WeatherService {
-	return &weatherService{nws: nws, cache: c}
+type options struct {
+	alerts   cache.KV
+	coverage domain.Coverage
+	profiles []domain.CategoryProfile
 }
 
-func (s *weatherService) GetTodayForecast(ctx context.Context, lat, lon float64) (domain.TodayForecast, error) {
-	key := cacheKey(lat, lon)
-	if v, ok := s.cache.Get(key); ok {
-		return v.(domain.TodayForecast), nil
+type Option func(*options)
+
+// WithAlertsCache caches active alerts in c, which should use a much shorter
+// TTL than the forecast cache. Without it alerts are always fetched.
+func WithAlertsCache(c cache.KV) Option {
+	return func(o *options) { o.alerts = c }
+}
+
+// WithCoverage rejects points outside c, such as domain.NWSCoverage, before
+// they reach the provider.
+func WithCoverage(c domain.Coverage) Option {
+	return func(o *options) { o.coverage = c }
+}
+
+// Service is the weather service; it can also be warmed by a Warmer.
+type Service interface {
+	ports.WeatherService
+	Warmable
+}
+
+func NewWeatherService(nws ports.NWSClient, c cache.KV, opts ...Option) Service {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	profiles := map[string]domain.CategoryProfile{domain.DefaultCategoryProfile: domain.DefaultProfile()}
+	for _, p := range o.profiles {
+		profiles[p.Name] = p
 	}
+	// a burst of misses for the same location produces a single upstream fetch
+	coalesced := cache.WithCoalescedHook(obs.CoalescedRequestsTotal.Inc)
+	return &weatherService{
+		nws:        nws,
+		today:      cache.NewTyped[domain.TodayForecast](c, "today:", coalesced),
+		daily:      cache.NewTyped[[]domain.ForecastPeriod](c, "daily:", coalesced),
+		hourly:     cache.NewTyped[[]domain.HourlyPeriod](c, "hourly:", coalesced),
+		conditions: cache.NewTyped[domain.CurrentConditions](c, "conditions:", coalesced),
+		alerts:     cache.NewTyped[[]domain.Alert](o.alerts, "alerts:", coalesced),
+		coverage:   o.coverage,
+		profiles:   profiles,
+	}
+}
+
+// RegisterCacheTypes registers the values the service caches so that
+// serializing cache backends can decode them. Names are versioned when the
+// shape of a value changes, so entries written before are dropped.
+func RegisterCacheTypes(c *cache.JSONCodec) {
+	c.Register("today.v2", domain.TodayForecast{})
+	c.Register("daily.v2", []domain.ForecastPeriod{})
+	c.Register("hourly.v2", []domain.HourlyPeriod{})
+	c.Register("alerts", []domain.Alert{})
+	c.Register("conditions.v2", domain.CurrentConditions{})
+}
 
-	short, tempF, err := s.nws.GetToday(ctx, lat, lon)
+func (s *weatherService) GetTodayForecast(ctx context.Context, lat, lon float64, profile string) (domain.TodayForecast, error) {
+	lat, lon, err := domain.NormalizeLatLon(lat, lon, s.coverage)
 	if err != nil {
 		return domain.TodayForecast{}, err
 	}
-
-	res := domain.TodayForecast{ShortForecast: short, TemperatureF: tempF, Category: categorize(tempF)}
-	s.cache.Set(key, res)
+	p, err := s.profile(profile)
+	if err != nil {
+		return domain.TodayForecast{}, err
+	}
+	key := cacheKey(lat, lon)
+	res, stale, ok := s.today.GetStale(key)
+	if !ok {
+		if res, err = s.today.Refresh(ctx, key, s.todayLoader(lat, lon)); err != nil {
+			return domain.TodayForecast{}, err
+		}
+	} else if stale {
+		// serve the stale value now, refresh behind the caller's back;
+		// if the refresh fails the stale value keeps being served
+		s.refreshToday(ctx, key, lat, lon)
+		obs.StaleServedTotal.Inc()
+		res.Stale = true
+	}
+	res.Category = categorize(p, res.Temperature, &res.FeelsLike)
 	return res, nil
 }
 
-func categorize(tempF float64) string {
+func (s *weatherService) todayLoader(lat, lon float64) cache.Loader[domain.TodayForecast] {
+	return func(ctx context.Context) (domain.TodayForecast, time.Duration, error) {
+		res, err := s.nws.GetToday(ctx, lat, lon)
+		if err != nil {
+			return domain.TodayForecast{}, 0, err
+		}
+		return res, ttlUntil(res.Expires), nil
+	}
+}
+
+// refreshToday starts at most one background refresh per key.
+func (s *weatherService) refreshToday(ctx context.Context, key string, lat, lon float64) {
+	if _, busy := s.refreshing.LoadOrStore(key, struct{}{}); busy {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer s.refreshing.Delete(key)
+		_, _ = s.today.Refresh(ctx, key, s.todayLoader(lat, lon))
+	}()
+}
+
+func (s *weatherService) GetForecast(ctx context.Context, lat, lon float64, days int) ([]domain.ForecastPeriod, error) {
+	lat, lon, err := domain.NormalizeLatLon(lat, lon, s.coverage)
+	if err != nil {
+		return nil, err
+	}
 	switch {
-	case tempF >= 85:
-		return "hot"
-	case tempF >= 60:
-		return "moderate"
-	default:
-		return "cold"
+	case days == 0:
+		days = domain.MaxForecastDays
+	case days < 0 || days > domain.MaxForecastDays:
+		return nil, &domain.ValidationError{Violations: []domain.FieldViolation{
+			domain.Violation("days", "must be between 1 and %d", domain.MaxForecastDays),
+		}}
+	}
+	key := fmt.Sprintf("%s:days=%d", cacheKey(lat, lon), days)
+	return s.daily.GetOrLoad(ctx, key, s.dailyLoader(lat, lon, days))
+}
+
+func (s *weatherService) dailyLoader(lat, lon float64, days int) cache.Loader[[]domain.ForecastPeriod] {
+	return func(ctx context.Context) ([]domain.ForecastPeriod, time.Duration, error) {
+		f, err := s.nws.GetForecast(ctx, lat, lon, days)
+		return f.Periods, ttlUntil(f.Expires), err
+	}
+}
+
+func (s *weatherService) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) ([]domain.HourlyPeriod, error) {
+	lat, lon, err := domain.NormalizeLatLon(lat, lon, s.coverage)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case hours == 0:
+		hours = domain.DefaultForecastHours
+	case hours < 0 || hours > domain.MaxForecastHours:
+		return nil, &domain.ValidationError{Violations: []domain.FieldViolation{
+			domain.Violation("hours", "must be between 1 and %d", domain.MaxForecastHours),
+		}}
+	}
+	key := fmt.Sprintf("%s:hours=%d", cacheKey(lat, lon), hours)
+	return s.hourly.GetOrLoad(ctx, key, func(ctx context.Context) ([]domain.HourlyPeriod, time.Duration, error) {
+		f, err := s.nws.GetHourlyForecast(ctx, lat, lon, hours)
+		return f.Hours, ttlUntil(f.Expires), err
+	})
+}
+
+func (s *weatherService) ListAlerts(ctx context.Context, q domain.AlertQuery) ([]domain.Alert, error) {
+	q, err := domain.NormalizeAlertQuery(q, s.coverage)
+	if err != nil {
+		return nil, err
+	}
+	key := cacheKey(q.Lat, q.Lon)
+	if q.Zone != "" {
+		key = "zone=" + q.Zone
+	}
+	return s.alerts.GetOrLoad(ctx, key, func(ctx context.Context) ([]domain.Alert, time.Duration, error) {
+		alerts, err := s.nws.GetActiveAlerts(ctx, q)
+		return alerts, 0, err
+	})
+}
+
+func (s *weatherService) GetCurrentConditions(ctx context.Context, lat, lon float64, profile string) (domain.CurrentConditions, error) {
+	lat, lon, err := domain.NormalizeLatLon(lat, lon, s.coverage)
+	if err != nil {
+		return domain.CurrentConditions{}, err
+	}
+	p, err := s.profile(profile)
+	if err != nil {
+		return domain.CurrentConditions{}, err
+	}
+	cc, err := s.conditions.GetOrLoad(ctx, cacheKey(lat, lon), func(ctx context.Context) (domain.CurrentConditions, time.Duration, error) {
+		cc, err := s.nws.GetCurrentConditions(ctx, lat, lon)
+		return cc, 0, err
+	})
+	if err != nil {
+		return domain.CurrentConditions{}, err
+	}
+	cc.Category = ""
+	if cc.Temperature != nil {
+		cc.Category = categorize(p, *cc.Temperature, cc.FeelsLike)
+	}
+	return cc, nil
+}
+
+// Warm reloads today's and the default daily forecast for a location, even
+// if they are still cached.
+func (s *weatherService) Warm(ctx context.Context, lat, lon float64) error {
+	lat, lon, err := domain.NormalizeLatLon(lat, lon, s.coverage)
+	if err != nil {
+		return err
+	}
+	key := cacheKey(lat, lon)
+	if _, err := s.today.Refresh(ctx, key, s.todayLoader(lat, lon)); err != nil {
+		return err
+	}
+	days := domain.MaxForecastDays
+	_, err = s.daily.Refresh(ctx, fmt.Sprintf("%s:days=%d", key, days), s.dailyLoader(lat, lon, days))
+	return err
+}
+
+// expiredTTL is how long a document the provider sends already expired is
+// cached, so that it is fetched again soon without a request per read.
+const expiredTTL = 30 * time.Second
+
+// ttlUntil caches forecasts for as long as the provider says they are fresh.
+// Without an expiry the cache default applies; a past one gets expiredTTL.
+func ttlUntil(expires time.Time) time.Duration {
+	if expires.IsZero() {
+		return 0
 	}
+	return max(time.Until(expires), expiredTTL)
 }
 
 func cacheKey(lat, lon float64) string {
@@ -2,29 +2,66 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/rcglezreyes/go_weather/internal/core/domain"
 	"github.com/rcglezreyes/go_weather/internal/pkg/cache"
 )
 
 type fakeNWS struct {
-	short string
-	temp  float64
-	err   error
+	short   string
+	temp    float64
+	periods []domain.ForecastPeriod
+	hours   []domain.HourlyPeriod
+	alerts  []domain.Alert
+	cc      domain.CurrentConditions
+	calls   *int
+	err     error
 }
 
-func (f fakeNWS) GetToday(ctx context.Context, lat, lon float64) (string, float64, error) {
-	return f.short, f.temp, f.err
+func (f fakeNWS) GetToday(ctx context.Context, lat, lon float64) (domain.TodayForecast, error) {
+	return domain.TodayForecast{ShortForecast: f.short, Temperature: degF(f.temp)}, f.err
+}
+
+func (f fakeNWS) GetForecast(ctx context.Context, lat, lon float64, days int) (domain.Forecast, error) {
+	if days != domain.MaxForecastDays {
+		return domain.Forecast{}, fmt.Errorf("want %d days, got %d", domain.MaxForecastDays, days)
+	}
+	return domain.Forecast{Periods: f.periods}, f.err
+}
+
+func (f fakeNWS) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int) (domain.HourlyForecast, error) {
+	if hours < len(f.hours) {
+		return domain.HourlyForecast{Hours: f.hours[:hours]}, f.err
+	}
+	return domain.HourlyForecast{Hours: f.hours}, f.err
+}
+
+func (f fakeNWS) GetActiveAlerts(ctx context.Context, q domain.AlertQuery) ([]domain.Alert, error) {
+	if f.calls != nil {
+		*f.calls++
+	}
+	return f.alerts, f.err
+}
+
+func (f fakeNWS) GetCurrentConditions(ctx context.Context, lat, lon float64) (domain.CurrentConditions, error) {
+	return f.cc, f.err
 }
 
 func TestGetTodayForecast_UsesCache(t *testing.T) {
 	c := cache.NewTTLCache(cache.Config{TTL: 60, SweepInterval: 10, MaxEntries: 100})
 	svc := NewWeatherService(fakeNWS{short: "Sunny", temp: 90}, c)
-	res1, err := svc.GetTodayForecast(context.Background(), 1.23456, 7.89012)
+	res1, err := svc.GetTodayForecast(context.Background(), 1.23456, 7.89012, "")
 	if err != nil {
 		t.Fatal(err)
 	}
-	res2, err := svc.GetTodayForecast(context.Background(), 1.23456, 7.89012)
+	res2, err := svc.GetTodayForecast(context.Background(), 1.23456, 7.89012, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -36,8 +73,274 @@ func TestGetTodayForecast_UsesCache(t *testing.T) {
 	}
 }
 
-func TestCategorize(t *testing.T) {
-	if categorize(85) != "hot" || categorize(60) != "moderate" || categorize(59.9) != "cold" {
-		t.Fatal("categorize thresholds failed")
+func TestGetForecast_DefaultsToMaxDays(t *testing.T) {
+	c := cache.NewTTLCache(cache.Config{TTL: 60, SweepInterval: 10, MaxEntries: 100})
+	nws := fakeNWS{periods: []domain.ForecastPeriod{{Name: "Today"}, {Name: "Tonight"}}}
+	svc := NewWeatherService(nws, c)
+	got, err := svc.GetForecast(context.Background(), 1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("want 2 periods, got %d", len(got))
+	}
+	for _, days := range []int{-1, 9} {
+		if _, err := svc.GetForecast(context.Background(), 1, 2, days); !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Fatalf("days=%d: want an invalid argument, got %v", days, err)
+		}
+	}
+}
+
+func TestGetHourlyForecast_ValidatesHours(t *testing.T) {
+	c := cache.NewTTLCache(cache.Config{TTL: 60, SweepInterval: 10, MaxEntries: 100})
+	nws := fakeNWS{hours: make([]domain.HourlyPeriod, 200)}
+	svc := NewWeatherService(nws, c)
+	for hours, want := range map[int]int{0: domain.DefaultForecastHours, 3: 3, domain.MaxForecastHours: domain.MaxForecastHours} {
+		got, err := svc.GetHourlyForecast(context.Background(), 1, 2, hours)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != want {
+			t.Fatalf("hours=%d: want %d entries, got %d", hours, want, len(got))
+		}
+	}
+	for _, hours := range []int{-1, domain.MaxForecastHours + 1, 10000} {
+		if _, err := svc.GetHourlyForecast(context.Background(), 1, 2, hours); !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Fatalf("hours=%d: want an invalid argument, got %v", hours, err)
+		}
+	}
+}
+
+func TestListAlerts_UsesAlertsCache(t *testing.T) {
+	c := cache.NewTTLCache(cache.Config{TTL: 60, SweepInterval: 10, MaxEntries: 100})
+	alerts := cache.NewTTLCache(cache.Config{TTL: 10, SweepInterval: 10, MaxEntries: 100})
+	calls := 0
+	nws := fakeNWS{alerts: []domain.Alert{{Event: "Heat Advisory"}}, calls: &calls}
+	svc := NewWeatherService(nws, c, WithAlertsCache(alerts))
+	for i := 0; i < 2; i++ {
+		got, err := svc.ListAlerts(context.Background(), domain.AlertQuery{Zone: "MDZ011"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0].Event != "Heat Advisory" {
+			t.Fatalf("unexpected alerts: %v", got)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("want 1 upstream call, got %d", calls)
+	}
+	if _, ok := c.Get("alerts:zone=MDZ011"); ok {
+		t.Fatal("alerts must not be stored in the forecast cache")
+	}
+}
+
+func TestGetCurrentConditions_CategorizesObservedTemp(t *testing.T) {
+	c := cache.NewTTLCache(cache.Config{TTL: 60, SweepInterval: 10, MaxEntries: 100})
+	temp := 5.0 // 41°F
+	svc := NewWeatherService(fakeNWS{cc: domain.CurrentConditions{StationID: "KDCA", Temperature: &domain.Quantity{Value: temp, Unit: domain.Celsius}}}, c)
+	got, err := svc.GetCurrentConditions(context.Background(), 38.85, -77.03, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Category != "cold" {
+		t.Fatalf("want cold, got %s", got.Category)
+	}
+
+	svc = NewWeatherService(fakeNWS{cc: domain.CurrentConditions{StationID: "KDCA"}}, c)
+	got, err = svc.GetCurrentConditions(context.Background(), 1, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Category != "" {
+		t.Fatalf("want no category without a temperature, got %s", got.Category)
 	}
 }
+
+type blockingNWS struct {
+	fakeNWS
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (b *blockingNWS) GetToday(ctx context.Context, lat, lon float64) (domain.TodayForecast, error) {
+	b.calls.Add(1)
+	<-b.release
+	return domain.TodayForecast{ShortForecast: "Sunny", Temperature: degF(70)}, nil
+}
+
+func TestGetTodayForecast_CoalescesConcurrentMisses(t *testing.T) {
+	c := cache.NewTTLCache(cache.Config{TTL: 60, SweepInterval: 10, MaxEntries: 100})
+	nws := &blockingNWS{release: make(chan struct{})}
+	svc := NewWeatherService(nws, c)
+
+	const callers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := svc.GetTodayForecast(context.Background(), 1, 2, "")
+			if err == nil && res.ShortForecast != "Sunny" {
+				err = fmt.Errorf("unexpected result %v", res)
+			}
+			errs <- err
+		}()
+	}
+	for nws.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond) // let the other callers join the in-flight fetch
+	close(nws.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := nws.calls.Load(); n != 1 {
+		t.Fatalf("want 1 upstream call, got %d", n)
+	}
+}
+
+// staleKV reports every stored value as stale.
+type staleKV struct {
+	mu sync.Mutex
+	m  map[string]any
+}
+
+func (k *staleKV) Get(key string) (any, bool) { return nil, false }
+
+func (k *staleKV) GetStale(key string) (any, bool, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	v, ok := k.m[key]
+	return v, true, ok
+}
+
+func (k *staleKV) Set(key string, v any) { k.SetWithTTL(key, v, 0) }
+
+func (k *staleKV) SetWithTTL(key string, v any, ttl time.Duration) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.m[key] = v
+}
+
+type countingNWS struct {
+	fakeNWS
+	calls atomic.Int32
+}
+
+func (c *countingNWS) GetToday(ctx context.Context, lat, lon float64) (domain.TodayForecast, error) {
+	c.calls.Add(1)
+	return c.fakeNWS.GetToday(ctx, lat, lon)
+}
+
+func TestGetTodayForecast_ServesStaleAndRefreshes(t *testing.T) {
+	kv := &staleKV{m: map[string]any{}}
+	kv.Set("today:"+cacheKey(1, 2), domain.TodayForecast{ShortForecast: "Old", Temperature: degF(50), Category: "cold"})
+	nws := &countingNWS{fakeNWS: fakeNWS{err: errors.New("nws down")}}
+	svc := NewWeatherService(nws, kv)
+
+	res, err := svc.GetTodayForecast(context.Background(), 1, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Stale || res.ShortForecast != "Old" {
+		t.Fatalf("want stale cached forecast, got %+v", res)
+	}
+	deadline := time.Now().Add(time.Second)
+	for nws.calls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if nws.calls.Load() == 0 {
+		t.Fatal("expected a background refresh")
+	}
+
+	// the failed refresh keeps the stale value in place
+	res, err = svc.GetTodayForecast(context.Background(), 1, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Stale || res.ShortForecast != "Old" {
+		t.Fatalf("want stale cached forecast after failed refresh, got %+v", res)
+	}
+}
+
+func TestRegisterCacheTypes_RoundTrip(t *testing.T) {
+	codec := cache.NewJSONCodec()
+	RegisterCacheTypes(codec)
+	temp := 41.0
+	for _, v := range []any{
+		domain.TodayForecast{ShortForecast: "Sunny", Temperature: degF(90), Category: "hot"},
+		[]domain.ForecastPeriod{{Name: "Tonight", StartTime: time.Date(2024, 7, 1, 18, 0, 0, 0, time.UTC)}},
+		[]domain.HourlyPeriod{{WindDirection: "NW"}},
+		[]domain.Alert{{Event: "Flood Watch", AffectedZones: []string{"MDZ011"}}},
+		domain.CurrentConditions{StationID: "KDCA", Temperature: &domain.Quantity{Value: temp, Unit: domain.Celsius}},
+	} {
+		b, err := codec.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := codec.Unmarshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprintf("%T", got) != fmt.Sprintf("%T", v) {
+			t.Fatalf("want %T, got %T", v, got)
+		}
+	}
+}
+
+type expiringNWS struct {
+	fakeNWS
+	expires time.Time
+}
+
+func (e expiringNWS) GetToday(ctx context.Context, lat, lon float64) (domain.TodayForecast, error) {
+	return domain.TodayForecast{ShortForecast: "Sunny", Temperature: degF(70), Expires: e.expires}, nil
+}
+
+func TestGetTodayForecast_TTLFollowsUpstreamExpiry(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		expires time.Time
+		want    float64
+	}{
+		{"upstream expiry", time.Now().Add(time.Hour), 3600},
+		{"unknown expiry", time.Time{}, 60},
+		{"already expired", time.Now().Add(-time.Minute), expiredTTL.Seconds()},
+		{"about to expire", time.Now().Add(time.Second), expiredTTL.Seconds()},
+	} {
+		c := cache.NewTTLCache(cache.Config{TTL: 60, MaxEntries: 10})
+		svc := NewWeatherService(expiringNWS{expires: tc.expires}, c)
+		if _, err := svc.GetTodayForecast(context.Background(), 1, 2, ""); err != nil {
+			t.Fatal(err)
+		}
+		info, ok := c.Entry("today:" + cacheKey(1, 2))
+		if !ok || info.TTL > tc.want || info.TTL < tc.want-2 {
+			t.Errorf("%s: want a TTL of %vs, got %+v", tc.name, tc.want, info)
+		}
+	}
+}
+
+func TestService_ValidatesCoordinates(t *testing.T) {
+	calls := 0
+	svc := NewWeatherService(fakeNWS{calls: &calls}, nil, WithCoverage(domain.NWSCoverage))
+	if _, err := svc.ListAlerts(context.Background(), domain.AlertQuery{Lat: math.NaN(), Lon: 1}); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("want an invalid argument, got %v", err)
+	}
+	if _, err := svc.ListAlerts(context.Background(), domain.AlertQuery{Lat: 51.5, Lon: -0.12}); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("want a point outside coverage rejected, got %v", err)
+	}
+	if _, err := svc.GetTodayForecast(context.Background(), 500, -999, ""); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("want an invalid argument, got %v", err)
+	}
+	if calls != 0 {
+		t.Fatal("invalid requests must not reach the provider")
+	}
+}
+
+func degF(v float64) domain.Quantity { return domain.Quantity{Value: v, Unit: domain.Fahrenheit} }
@@ -0,0 +1,155 @@
+// Package breaker implements a circuit breaker that fails calls fast while a
+// dependency is down.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by Allow while the breaker is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+type Config struct {
+	// FailureThreshold consecutive failures open the breaker (default 5).
+	FailureThreshold int
+	// CoolDown is how long the breaker stays open before letting probe
+	// calls through (default 30s).
+	CoolDown time.Duration
+	// HalfOpenProbes is the number of concurrent calls allowed while
+	// half-open (default 1).
+	HalfOpenProbes int
+	// OnStateChange, if set, is called with the new state on every
+	// transition, with the breaker locked.
+	OnStateChange func(State)
+}
+
+// Breaker is closed while calls succeed. FailureThreshold consecutive
+// failures open it and every call fails with ErrOpen for CoolDown; then it
+// turns half-open and lets HalfOpenProbes calls through: a success closes it,
+// a failure opens it again.
+//
+// A nil *Breaker allows every call.
+type Breaker struct {
+	cfg Config
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+func New(cfg Config) *Breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.CoolDown <= 0 {
+		cfg.CoolDown = 30 * time.Second
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = 1
+	}
+	return &Breaker{cfg: cfg, now: time.Now}
+}
+
+// Allow reports whether a call may proceed. Every allowed call must be
+// followed by Done.
+func (b *Breaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == Open {
+		if b.now().Sub(b.openedAt) < b.cfg.CoolDown {
+			return ErrOpen
+		}
+		b.setState(HalfOpen)
+		b.probes = 0
+	}
+	if b.state == HalfOpen {
+		if b.probes >= b.cfg.HalfOpenProbes {
+			return ErrOpen
+		}
+		b.probes++
+	}
+	return nil
+}
+
+// Done records the outcome of an allowed call. Calls canceled by their caller
+// say nothing about the dependency and are not counted.
+func (b *Breaker) Done(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case err == nil:
+		b.failures = 0
+		if b.state == HalfOpen {
+			b.setState(Closed)
+		}
+	case errors.Is(err, context.Canceled):
+		if b.state == HalfOpen && b.probes > 0 {
+			b.probes--
+		}
+	default:
+		b.failures++
+		if b.state == HalfOpen || (b.state == Closed && b.failures >= b.cfg.FailureThreshold) {
+			b.openedAt = b.now()
+			b.setState(Open)
+		}
+	}
+}
+
+// State returns the current state; an open breaker whose cool-down elapsed
+// is reported half-open.
+func (b *Breaker) State() State {
+	if b == nil {
+		return Closed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == Open && b.now().Sub(b.openedAt) >= b.cfg.CoolDown {
+		return HalfOpen
+	}
+	return b.state
+}
+
+func (b *Breaker) setState(s State) {
+	if b.state == s {
+		return
+	}
+	b.state = s
+	if s == Closed {
+		b.failures = 0
+	}
+	if b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(s)
+	}
+}
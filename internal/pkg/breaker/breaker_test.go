@@ -0,0 +1,87 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker_Transitions(t *testing.T) {
+	now := time.Unix(0, 0)
+	var changes []State
+	b := New(Config{FailureThreshold: 2, CoolDown: time.Minute, OnStateChange: func(s State) { changes = append(changes, s) }})
+	b.now = func() time.Time { return now }
+	fail := errors.New("boom")
+
+	call := func(err error) error {
+		if e := b.Allow(); e != nil {
+			return e
+		}
+		b.Done(err)
+		return nil
+	}
+
+	// a success resets the count of consecutive failures
+	_ = call(fail)
+	_ = call(nil)
+	_ = call(fail)
+	if b.State() != Closed {
+		t.Fatal("want closed below the threshold")
+	}
+	_ = call(fail)
+	if b.State() != Open {
+		t.Fatal("want open at the threshold")
+	}
+	if err := call(nil); !errors.Is(err, ErrOpen) {
+		t.Fatalf("want ErrOpen, got %v", err)
+	}
+
+	// after the cool-down a single probe goes through; a failure reopens
+	now = now.Add(time.Minute)
+	if b.State() != HalfOpen {
+		t.Fatal("want half-open after the cool-down")
+	}
+	if err := b.Allow(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatal("want one probe at a time")
+	}
+	b.Done(fail)
+	if b.State() != Open {
+		t.Fatal("want open after a failed probe")
+	}
+
+	// a canceled probe frees its slot, a successful one closes
+	now = now.Add(time.Minute)
+	_ = b.Allow()
+	b.Done(context.Canceled)
+	if err := call(nil); err != nil {
+		t.Fatal(err)
+	}
+	if b.State() != Closed {
+		t.Fatal("want closed after a successful probe")
+	}
+
+	want := []State{Open, HalfOpen, Open, HalfOpen, Closed}
+	if len(changes) != len(want) {
+		t.Fatalf("want transitions %v, got %v", want, changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("want transitions %v, got %v", want, changes)
+		}
+	}
+}
+
+func TestBreaker_NilAllowsEverything(t *testing.T) {
+	var b *Breaker
+	if err := b.Allow(); err != nil {
+		t.Fatal(err)
+	}
+	b.Done(errors.New("boom"))
+	if b.State() != Closed {
+		t.Fatal("want closed")
+	}
+}
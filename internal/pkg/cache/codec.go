@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Codec serializes cached values for backends that store bytes.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(b []byte) (any, error)
+}
+
+// JSONCodec encodes values as JSON tagged with the name they were registered
+// under, so they decode back to the same Go type. Values of unregistered
+// types cannot be encoded.
+type JSONCodec struct {
+	mu     sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}
+
+func NewJSONCodec() *JSONCodec {
+	return &JSONCodec{byName: map[string]reflect.Type{}, byType: map[reflect.Type]string{}}
+}
+
+// Register makes values of sample's type encodable under name.
+func (c *JSONCodec) Register(name string, sample any) {
+	t := reflect.TypeOf(sample)
+	c.mu.Lock()
+	c.byName[name] = t
+	c.byType[t] = name
+	c.mu.Unlock()
+}
+
+type envelope struct {
+	Type  string          `json:"t"`
+	Value json.RawMessage `json:"v"`
+}
+
+func (c *JSONCodec) Marshal(v any) ([]byte, error) {
+	c.mu.RLock()
+	name, ok := c.byType[reflect.TypeOf(v)]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("cache codec: unregistered type %T", v)
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope{Type: name, Value: raw})
+}
+
+func (c *JSONCodec) Unmarshal(b []byte) (any, error) {
+	var env envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	t, ok := c.byName[env.Type]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("cache codec: unregistered type name %q", env.Type)
+	}
+	p := reflect.New(t)
+	if err := json.Unmarshal(env.Value, p.Interface()); err != nil {
+		return nil, err
+	}
+	return p.Elem().Interface(), nil
+}
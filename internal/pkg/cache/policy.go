@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"container/list"
+	"time"
+)
+
+// Policy names an eviction policy for the TTL cache.
+type Policy string
+
+const (
+	// PolicyLRU evicts the least recently used entry.
+	PolicyLRU Policy = "lru"
+	// PolicyLFU evicts the least frequently used entry, breaking ties by
+	// recency.
+	PolicyLFU Policy = "lfu"
+)
+
+type entry struct {
+	key string
+	it  item
+
+	elem *list.Element // position in the recency list (LRU) or its frequency bucket (LFU)
+	freq *list.Element // LFU frequency bucket
+}
+
+func (e *entry) info(now time.Time) EntryInfo {
+	info := EntryInfo{Key: e.key}
+	if e.it.exp > 0 {
+		info.ExpiresAt = time.Unix(e.it.exp, 0)
+		info.StaleUntil = time.Unix(e.it.stale, 0)
+		info.TTL = info.ExpiresAt.Sub(now).Seconds()
+	}
+	return info
+}
+
+// evictionPolicy tracks entries in O(1) per operation. Callers hold the
+// store lock.
+type evictionPolicy interface {
+	add(e *entry)
+	touch(e *entry)
+	remove(e *entry)
+	victim() *entry
+}
+
+func newPolicy(p Policy) evictionPolicy {
+	if p == PolicyLFU {
+		return &lfu{buckets: list.New()}
+	}
+	return &lru{ll: list.New()}
+}
+
+// lru keeps entries most recently used first.
+type lru struct{ ll *list.List }
+
+func (l *lru) add(e *entry)    { e.elem = l.ll.PushFront(e) }
+func (l *lru) touch(e *entry)  { l.ll.MoveToFront(e.elem) }
+func (l *lru) remove(e *entry) { l.ll.Remove(e.elem) }
+
+func (l *lru) victim() *entry {
+	if b := l.ll.Back(); b != nil {
+		return b.Value.(*entry)
+	}
+	return nil
+}
+
+// lfu keeps a list of frequency buckets in ascending order; each bucket holds
+// its entries most recently used first.
+type lfu struct{ buckets *list.List }
+
+type bucket struct {
+	n       int
+	entries *list.List
+}
+
+func (l *lfu) add(e *entry) {
+	front := l.buckets.Front()
+	if front == nil || front.Value.(*bucket).n != 1 {
+		front = l.buckets.PushFront(&bucket{n: 1, entries: list.New()})
+	}
+	e.freq = front
+	e.elem = front.Value.(*bucket).entries.PushFront(e)
+}
+
+func (l *lfu) touch(e *entry) {
+	cur := e.freq
+	b := cur.Value.(*bucket)
+	next := cur.Next()
+	if next == nil || next.Value.(*bucket).n != b.n+1 {
+		next = l.buckets.InsertAfter(&bucket{n: b.n + 1, entries: list.New()}, cur)
+	}
+	b.entries.Remove(e.elem)
+	e.freq = next
+	e.elem = next.Value.(*bucket).entries.PushFront(e)
+	if b.entries.Len() == 0 {
+		l.buckets.Remove(cur)
+	}
+}
+
+func (l *lfu) remove(e *entry) {
+	b := e.freq.Value.(*bucket)
+	b.entries.Remove(e.elem)
+	if b.entries.Len() == 0 {
+		l.buckets.Remove(e.freq)
+	}
+}
+
+func (l *lfu) victim() *entry {
+	front := l.buckets.Front()
+	if front == nil {
+		return nil
+	}
+	if b := front.Value.(*bucket).entries.Back(); b != nil {
+		return b.Value.(*entry)
+	}
+	return nil
+}
@@ -0,0 +1,462 @@
+package cache
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// RedisConfig configures a KV stored in a Redis-protocol server shared by
+// every replica of the service.
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+	// KeyPrefix namespaces the keys of this cache, e.g. "go_weather:forecast:".
+	KeyPrefix string
+
+	TTL      int // seconds
+	StaleTTL int // seconds
+
+	PoolSize     int
+	DialTimeout  time.Duration
+	IOTimeout    time.Duration
+	RetryBackoff time.Duration // how long to stay on the fallback after a failure
+
+	// Codec serializes values; required.
+	Codec Codec
+	// Fallback serves requests while the backend is unreachable; nil means
+	// requests miss instead.
+	Fallback KV
+}
+
+// RedisKV is the KV NewRedisCache returns. Inspecting it reads the shared
+// keys, so deleting or purging entries affects every replica.
+type RedisKV interface {
+	KV
+	Inspector
+}
+
+type redisStore struct {
+	cfg  RedisConfig
+	pool chan *redisConn
+	sem  chan struct{} // bounds open connections
+
+	downUntil atomic.Int64 // unix nanos; backend skipped until then
+	down      atomic.Bool
+
+	// lookups and writes of this replica, whichever store served them
+	hits, misses, sets atomic.Uint64
+}
+
+type redisConn struct {
+	c net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// redisItem is what is stored under each key.
+type redisItem struct {
+	Exp   int64           `json:"e"`
+	Stale int64           `json:"s"`
+	Value json.RawMessage `json:"v"`
+}
+
+func NewRedisCache(cfg RedisConfig) RedisKV {
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 10
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = 500 * time.Millisecond
+	}
+	if cfg.IOTimeout <= 0 {
+		cfg.IOTimeout = 500 * time.Millisecond
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = 5 * time.Second
+	}
+	return &redisStore{
+		cfg:  cfg,
+		pool: make(chan *redisConn, cfg.PoolSize),
+		sem:  make(chan struct{}, cfg.PoolSize),
+	}
+}
+
+func (s *redisStore) Get(key string) (any, bool) {
+	v, stale, ok := s.get(key)
+	if !ok || stale {
+		s.misses.Add(1)
+		return nil, false
+	}
+	s.hits.Add(1)
+	return v, true
+}
+
+func (s *redisStore) GetStale(key string) (any, bool, bool) {
+	v, stale, ok := s.get(key)
+	if ok {
+		s.hits.Add(1)
+	} else {
+		s.misses.Add(1)
+	}
+	return v, stale, ok
+}
+
+func (s *redisStore) get(key string) (any, bool, bool) {
+	if !s.available() {
+		return s.fallbackGet(key)
+	}
+	reply, err := s.do([]byte("GET"), []byte(s.cfg.KeyPrefix+key))
+	if errors.Is(err, errNil) {
+		return nil, false, false
+	}
+	if err != nil {
+		s.markDown(err)
+		return s.fallbackGet(key)
+	}
+	b, _ := reply.([]byte)
+	var it redisItem
+	if err := json.Unmarshal(b, &it); err != nil {
+		return nil, false, false
+	}
+	now := time.Now().Unix()
+	if it.Stale > 0 && it.Stale < now {
+		return nil, false, false
+	}
+	v, err := s.cfg.Codec.Unmarshal(it.Value)
+	if err != nil {
+		return nil, false, false
+	}
+	return v, it.Exp > 0 && it.Exp < now, true
+}
+
+func (s *redisStore) fallbackGet(key string) (any, bool, bool) {
+	if s.cfg.Fallback == nil {
+		return nil, false, false
+	}
+	return s.cfg.Fallback.GetStale(key)
+}
+
+func (s *redisStore) Set(key string, v any) { s.SetWithTTL(key, v, 0) }
+
+func (s *redisStore) SetWithTTL(key string, v any, ttl time.Duration) {
+	s.sets.Add(1)
+	if s.cfg.Fallback != nil {
+		// keep the local copy warm so an outage does not start cold
+		s.cfg.Fallback.SetWithTTL(key, v, ttl)
+	}
+	if !s.available() {
+		return
+	}
+	raw, err := s.cfg.Codec.Marshal(v)
+	if err != nil {
+		log.Printf("cache: redis encode %q: %v", key, err)
+		return
+	}
+	now := time.Now()
+	if ttl <= 0 {
+		ttl = time.Duration(s.cfg.TTL) * time.Second
+	}
+	staleTTL := time.Duration(s.cfg.StaleTTL) * time.Second
+	it := redisItem{Exp: expiry(now, ttl), Value: raw}
+	if it.Exp > 0 {
+		it.Stale = expiry(now, ttl+staleTTL)
+	}
+	b, err := json.Marshal(it)
+	if err != nil {
+		return
+	}
+
+	args := [][]byte{[]byte("SET"), []byte(s.cfg.KeyPrefix + key), b}
+	if it.Exp > 0 {
+		args = append(args, []byte("PX"), []byte(strconv.FormatInt((ttl+staleTTL).Milliseconds(), 10)))
+	}
+	if _, err := s.do(args...); err != nil {
+		s.markDown(err)
+	}
+}
+
+// Stats counts the lookups and writes of this replica. Entries is the number
+// of shared keys; Redis expires and evicts them on its own, so Evictions and
+// Expirations stay zero. While the backend is unreachable Entries is that of
+// the fallback.
+func (s *redisStore) Stats() Stats {
+	st := Stats{Hits: s.hits.Load(), Misses: s.misses.Load(), Sets: s.sets.Load()}
+	n := 0
+	err := s.scan(func(keys [][]byte) error {
+		n += len(keys)
+		return nil
+	})
+	if err == nil {
+		st.Entries = n
+	} else if in, ok := s.cfg.Fallback.(Inspector); ok {
+		st.Entries = in.Stats().Entries
+	}
+	return st
+}
+
+// Keys lists the shared keys, or those of the fallback while the backend is
+// unreachable.
+func (s *redisStore) Keys() []EntryInfo {
+	now := time.Now()
+	var out []EntryInfo
+	err := s.scan(func(keys [][]byte) error {
+		if len(keys) == 0 {
+			return nil
+		}
+		reply, err := s.do(append([][]byte{[]byte("MGET")}, keys...)...)
+		if err != nil {
+			return err
+		}
+		values, _ := reply.([]any)
+		for i, v := range values {
+			b, ok := v.([]byte)
+			if !ok || i >= len(keys) {
+				continue // expired since the SCAN
+			}
+			var it redisItem
+			if json.Unmarshal(b, &it) == nil {
+				out = append(out, it.info(strings.TrimPrefix(string(keys[i]), s.cfg.KeyPrefix), now))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if in, ok := s.cfg.Fallback.(Inspector); ok {
+			return in.Keys()
+		}
+		return nil
+	}
+	return out
+}
+
+func (s *redisStore) Entry(key string) (EntryInfo, bool) {
+	if !s.available() {
+		return s.fallbackEntry(key)
+	}
+	reply, err := s.do([]byte("GET"), []byte(s.cfg.KeyPrefix+key))
+	if errors.Is(err, errNil) {
+		return EntryInfo{}, false
+	}
+	if err != nil {
+		s.markDown(err)
+		return s.fallbackEntry(key)
+	}
+	b, _ := reply.([]byte)
+	var it redisItem
+	if err := json.Unmarshal(b, &it); err != nil {
+		return EntryInfo{}, false
+	}
+	info := it.info(key, time.Now())
+	if v, err := s.cfg.Codec.Unmarshal(it.Value); err == nil {
+		info.Value = v
+	}
+	return info, true
+}
+
+func (s *redisStore) fallbackEntry(key string) (EntryInfo, bool) {
+	if in, ok := s.cfg.Fallback.(Inspector); ok {
+		return in.Entry(key)
+	}
+	return EntryInfo{}, false
+}
+
+// Delete removes key from the backend and from the fallback, so an outage
+// does not bring the entry back.
+func (s *redisStore) Delete(key string) bool {
+	deleted := false
+	if in, ok := s.cfg.Fallback.(Inspector); ok {
+		deleted = in.Delete(key)
+	}
+	if !s.available() {
+		return deleted
+	}
+	reply, err := s.do([]byte("DEL"), []byte(s.cfg.KeyPrefix+key))
+	if err != nil {
+		s.markDown(err)
+		return deleted
+	}
+	n, _ := reply.(int64)
+	return deleted || n > 0
+}
+
+// Purge removes every shared key and empties the fallback.
+func (s *redisStore) Purge() {
+	if in, ok := s.cfg.Fallback.(Inspector); ok {
+		in.Purge()
+	}
+	err := s.scan(func(keys [][]byte) error {
+		if len(keys) == 0 {
+			return nil
+		}
+		_, err := s.do(append([][]byte{[]byte("DEL")}, keys...)...)
+		return err
+	})
+	if err != nil {
+		log.Printf("cache: redis purge %s: %v", s.cfg.KeyPrefix, err)
+	}
+}
+
+// scan calls fn with each batch of keys under KeyPrefix, prefix included.
+// It fails without a round trip while the backend is marked down.
+func (s *redisStore) scan(fn func(keys [][]byte) error) error {
+	if !s.available() {
+		return errors.New("redis: backend unavailable")
+	}
+	match := []byte(globEscaper.Replace(s.cfg.KeyPrefix) + "*")
+	cursor := []byte("0")
+	for {
+		reply, err := s.do([]byte("SCAN"), cursor, []byte("MATCH"), match, []byte("COUNT"), []byte("1000"))
+		if err != nil {
+			s.markDown(err)
+			return err
+		}
+		parts, _ := reply.([]any)
+		if len(parts) != 2 {
+			return fmt.Errorf("redis: unexpected SCAN reply %v", reply)
+		}
+		cursor, _ = parts[0].([]byte)
+		items, _ := parts[1].([]any)
+		keys := make([][]byte, 0, len(items))
+		for _, k := range items {
+			if b, ok := k.([]byte); ok {
+				keys = append(keys, b)
+			}
+		}
+		if err := fn(keys); err != nil {
+			s.markDown(err)
+			return err
+		}
+		if string(cursor) == "0" || len(cursor) == 0 {
+			return nil
+		}
+	}
+}
+
+// globEscaper quotes the pattern characters of SCAN MATCH.
+var globEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+func (it redisItem) info(key string, now time.Time) EntryInfo {
+	info := EntryInfo{Key: key}
+	if it.Exp > 0 {
+		info.ExpiresAt = time.Unix(it.Exp, 0)
+		info.StaleUntil = time.Unix(it.Stale, 0)
+		info.TTL = info.ExpiresAt.Sub(now).Seconds()
+	}
+	return info
+}
+
+func (s *redisStore) available() bool {
+	return time.Now().UnixNano() >= s.downUntil.Load()
+}
+
+// markDown routes traffic to the fallback for RetryBackoff. Server error
+// replies do not count: the backend is reachable.
+func (s *redisStore) markDown(err error) {
+	var re respError
+	if errors.As(err, &re) {
+		log.Printf("cache: redis %s: %v", s.cfg.Addr, err)
+		return
+	}
+	s.downUntil.Store(time.Now().Add(s.cfg.RetryBackoff).UnixNano())
+	if s.down.CompareAndSwap(false, true) {
+		log.Printf("cache: redis %s unreachable, using local cache: %v", s.cfg.Addr, err)
+	}
+}
+
+func (s *redisStore) markUp() {
+	if s.down.CompareAndSwap(true, false) {
+		log.Printf("cache: redis %s reachable again", s.cfg.Addr)
+	}
+}
+
+// do runs one command on a pooled connection. Connections are discarded on
+// any I/O error.
+func (s *redisStore) do(args ...[]byte) (any, error) {
+	cn, err := s.conn()
+	if err != nil {
+		return nil, err
+	}
+	_ = cn.c.SetDeadline(time.Now().Add(s.cfg.IOTimeout))
+	if err := writeCommand(cn.w, args...); err != nil {
+		s.discard(cn)
+		return nil, err
+	}
+	reply, err := readReply(cn.r)
+	var re respError
+	if err != nil && !errors.Is(err, errNil) && !errors.As(err, &re) {
+		s.discard(cn)
+		return nil, err
+	}
+	s.release(cn)
+	s.markUp()
+	return reply, err
+}
+
+func (s *redisStore) conn() (*redisConn, error) {
+	select {
+	case cn := <-s.pool:
+		return cn, nil
+	default:
+	}
+	select {
+	case cn := <-s.pool:
+		return cn, nil
+	case s.sem <- struct{}{}:
+	case <-time.After(s.cfg.IOTimeout):
+		return nil, errors.New("redis: connection pool exhausted")
+	}
+
+	c, err := net.DialTimeout("tcp", s.cfg.Addr, s.cfg.DialTimeout)
+	if err != nil {
+		<-s.sem
+		return nil, err
+	}
+	cn := &redisConn{c: c, r: bufio.NewReader(c), w: bufio.NewWriter(c)}
+	_ = c.SetDeadline(time.Now().Add(s.cfg.IOTimeout))
+	if s.cfg.Password != "" {
+		if err := s.handshake(cn, []byte("AUTH"), []byte(s.cfg.Password)); err != nil {
+			return nil, err
+		}
+	}
+	if s.cfg.DB != 0 {
+		if err := s.handshake(cn, []byte("SELECT"), []byte(strconv.Itoa(s.cfg.DB))); err != nil {
+			return nil, err
+		}
+	}
+	return cn, nil
+}
+
+func (s *redisStore) handshake(cn *redisConn, args ...[]byte) error {
+	err := writeCommand(cn.w, args...)
+	if err == nil {
+		_, err = readReply(cn.r)
+	}
+	if err != nil {
+		s.discard(cn)
+		// a rejected AUTH or SELECT leaves the backend as unusable as an
+		// unreachable one, so it must not pass for a server error reply
+		return fmt.Errorf("redis %s: %v", args[0], err)
+	}
+	return nil
+}
+
+func (s *redisStore) release(cn *redisConn) {
+	select {
+	case s.pool <- cn:
+	default:
+		s.discard(cn)
+	}
+}
+
+func (s *redisStore) discard(cn *redisConn) {
+	_ = cn.c.Close()
+	<-s.sem
+}
@@ -0,0 +1,280 @@
+package cache
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// respServer is an in-process stand-in for Redis implementing the commands
+// the backend uses.
+type respServer struct {
+	ln net.Listener
+	// password, when set, is the only one AUTH accepts
+	password string
+
+	mu   sync.Mutex
+	data map[string][]byte
+	exp  map[string]time.Time
+	cmds []string
+}
+
+func newRESPServer(t *testing.T) *respServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &respServer{ln: ln, data: map[string][]byte{}, exp: map[string]time.Time{}}
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *respServer) serve(c net.Conn) {
+	defer c.Close()
+	r, w := bufio.NewReader(c), bufio.NewWriter(c)
+	for {
+		req, err := readReply(r)
+		if err != nil {
+			return
+		}
+		parts, _ := req.([]any)
+		args := make([]string, len(parts))
+		for i, p := range parts {
+			b, _ := p.([]byte)
+			args[i] = string(b)
+		}
+		w.WriteString(s.exec(args))
+		if w.Flush() != nil {
+			return
+		}
+	}
+}
+
+func (s *respServer) exec(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cmd := strings.ToUpper(args[0])
+	s.cmds = append(s.cmds, cmd)
+	switch cmd {
+	case "AUTH":
+		if s.password != "" && args[1] != s.password {
+			return "-WRONGPASS invalid username-password pair\r\n"
+		}
+		return "+OK\r\n"
+	case "PING", "SELECT":
+		return "+OK\r\n"
+	case "SET":
+		s.data[args[1]] = []byte(args[2])
+		delete(s.exp, args[1])
+		if len(args) == 5 && strings.ToUpper(args[3]) == "PX" {
+			ms, _ := strconv.Atoi(args[4])
+			s.exp[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "+OK\r\n"
+	case "GET":
+		return s.get(args[1])
+	case "MGET":
+		out := "*" + strconv.Itoa(len(args)-1) + "\r\n"
+		for _, k := range args[1:] {
+			out += s.get(k)
+		}
+		return out
+	case "DEL":
+		n := 0
+		for _, k := range args[1:] {
+			if _, ok := s.data[k]; ok {
+				n++
+			}
+			delete(s.data, k)
+			delete(s.exp, k)
+		}
+		return ":" + strconv.Itoa(n) + "\r\n"
+	case "SCAN":
+		// one page holding every match; args are cursor MATCH pattern COUNT n
+		var keys []string
+		for k := range s.data {
+			if ok, _ := path.Match(args[3], k); ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		out := "*2\r\n$1\r\n0\r\n*" + strconv.Itoa(len(keys)) + "\r\n"
+		for _, k := range keys {
+			out += "$" + strconv.Itoa(len(k)) + "\r\n" + k + "\r\n"
+		}
+		return out
+	}
+	return "-ERR unknown command '" + cmd + "'\r\n"
+}
+
+func (s *respServer) get(key string) string {
+	v, ok := s.data[key]
+	if exp, has := s.exp[key]; !ok || (has && time.Now().After(exp)) {
+		return "$-1\r\n"
+	}
+	return "$" + strconv.Itoa(len(v)) + "\r\n" + string(v) + "\r\n"
+}
+
+type forecast struct {
+	Short string
+	TempF float64
+}
+
+func newTestCodec() *JSONCodec {
+	codec := NewJSONCodec()
+	codec.Register("forecast", forecast{})
+	codec.Register("forecasts", []forecast{})
+	return codec
+}
+
+func TestRedis_RoundTripsTypedValues(t *testing.T) {
+	srv := newRESPServer(t)
+	c := NewRedisCache(RedisConfig{Addr: srv.ln.Addr().String(), KeyPrefix: "t:", TTL: 60, Password: "secret", DB: 2, Codec: newTestCodec()})
+
+	c.Set("a", forecast{Short: "Sunny", TempF: 88})
+	c.Set("b", []forecast{{Short: "Rain"}})
+
+	v, ok := c.Get("a")
+	if !ok || v.(forecast) != (forecast{Short: "Sunny", TempF: 88}) {
+		t.Fatalf("unexpected value %#v %v", v, ok)
+	}
+	v, ok = c.Get("b")
+	if !ok || v.([]forecast)[0].Short != "Rain" {
+		t.Fatalf("unexpected value %#v %v", v, ok)
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("missing key should miss")
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if _, ok := srv.data["t:a"]; !ok {
+		t.Fatal("keys should carry the prefix")
+	}
+	if srv.cmds[0] != "AUTH" || srv.cmds[1] != "SELECT" {
+		t.Fatalf("want AUTH and SELECT on connect, got %v", srv.cmds)
+	}
+}
+
+func TestRedis_StaleWindow(t *testing.T) {
+	srv := newRESPServer(t)
+	c := NewRedisCache(RedisConfig{Addr: srv.ln.Addr().String(), TTL: 60, StaleTTL: 60, Codec: newTestCodec()})
+	c.Set("a", forecast{Short: "Sunny"})
+
+	// age the stored item past its freshness
+	srv.mu.Lock()
+	var it redisItem
+	if err := json.Unmarshal(srv.data["a"], &it); err != nil {
+		t.Fatal(err)
+	}
+	it.Exp = time.Now().Add(-time.Second).Unix()
+	srv.data["a"], _ = json.Marshal(it)
+	srv.mu.Unlock()
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get should not return stale values")
+	}
+	v, stale, ok := c.GetStale("a")
+	if !ok || !stale || v.(forecast).Short != "Sunny" {
+		t.Fatalf("want stale value, got %v %v %v", v, stale, ok)
+	}
+}
+
+func TestRedis_FallsBackWhenUnreachable(t *testing.T) {
+	srv := newRESPServer(t)
+	addr := srv.ln.Addr().String()
+	srv.ln.Close()
+
+	local := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+	c := NewRedisCache(RedisConfig{Addr: addr, TTL: 60, Codec: newTestCodec(), Fallback: local, DialTimeout: 50 * time.Millisecond})
+
+	c.Set("a", forecast{Short: "Sunny"})
+	start := time.Now()
+	v, ok := c.Get("a")
+	if !ok || v.(forecast).Short != "Sunny" {
+		t.Fatalf("want fallback value, got %v %v", v, ok)
+	}
+	if time.Since(start) > 40*time.Millisecond {
+		t.Fatal("backend should be skipped after a failure instead of dialing again")
+	}
+}
+
+func TestRedis_FallsBackWhenAuthFails(t *testing.T) {
+	srv := newRESPServer(t)
+	srv.mu.Lock()
+	srv.password = "secret"
+	srv.mu.Unlock()
+	local := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+	c := NewRedisCache(RedisConfig{Addr: srv.ln.Addr().String(), TTL: 60, Password: "wrong", Codec: newTestCodec(), Fallback: local})
+
+	c.Set("a", forecast{Short: "Sunny"})
+	v, ok := c.Get("a")
+	if !ok || v.(forecast).Short != "Sunny" {
+		t.Fatalf("want fallback value, got %v %v", v, ok)
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if len(srv.cmds) != 1 || srv.cmds[0] != "AUTH" {
+		t.Fatalf("want a single rejected AUTH before falling back, got %v", srv.cmds)
+	}
+}
+
+func TestRedis_InspectsSharedKeys(t *testing.T) {
+	srv := newRESPServer(t)
+	newReplica := func(prefix string) RedisKV {
+		return NewRedisCache(RedisConfig{Addr: srv.ln.Addr().String(), KeyPrefix: prefix, TTL: 60, Codec: newTestCodec(),
+			Fallback: NewTTLCache(Config{TTL: 60, MaxEntries: 10})})
+	}
+	a, b, other := newReplica("fc:"), newReplica("fc:"), newReplica("alerts:")
+	a.Set("39.74,-104.99", forecast{Short: "Sunny"})
+	a.Set("40.71,-74.01", forecast{Short: "Rain"})
+	other.Set("39.74,-104.99", forecast{Short: "Wind"})
+
+	if st := b.Stats(); st.Entries != 2 || st.Sets != 0 {
+		t.Fatalf("stats of the other replica = %+v, want 2 shared entries and no sets", st)
+	}
+	keys := b.Keys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Key < keys[j].Key })
+	if len(keys) != 2 || keys[0].Key != "39.74,-104.99" || keys[0].TTL <= 0 {
+		t.Fatalf("keys = %+v", keys)
+	}
+	info, ok := b.Entry("40.71,-74.01")
+	if !ok || info.Value.(forecast).Short != "Rain" || info.ExpiresAt.IsZero() {
+		t.Fatalf("entry = %+v %v", info, ok)
+	}
+
+	if !b.Delete("40.71,-74.01") || b.Delete("40.71,-74.01") {
+		t.Fatal("want the first delete to report the shared key and the second none")
+	}
+	if _, ok := a.Get("40.71,-74.01"); ok {
+		t.Fatal("key deleted through one replica is still served by another")
+	}
+
+	b.Purge()
+	if _, ok := a.Get("39.74,-104.99"); ok {
+		t.Fatal("purge left a shared key")
+	}
+	if _, ok := other.Get("39.74,-104.99"); !ok {
+		t.Fatal("purge removed keys of another prefix")
+	}
+	if st := a.Stats(); st.Entries != 0 || st.Sets != 2 || st.Misses != 2 {
+		t.Fatalf("stats after purge = %+v", st)
+	}
+}
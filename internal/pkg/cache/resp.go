@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Minimal RESP2 (Redis serialization protocol) encoding, enough for the
+// handful of commands the Redis backend issues.
+
+var errNil = errors.New("resp: nil reply")
+
+// respError is an error reply sent by the server.
+type respError string
+
+func (e respError) Error() string { return "redis: " + string(e) }
+
+func writeCommand(w *bufio.Writer, args ...[]byte) error {
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(w, "$%d\r\n", len(a))
+		w.Write(a)
+		w.WriteString("\r\n")
+	}
+	return w.Flush()
+}
+
+// readReply returns a string for simple strings, []byte for bulk strings,
+// int64 for integers and []any for arrays. Nil bulk strings and arrays yield
+// errNil; error replies yield a respError.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("resp: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return string(line[1:]), nil
+	case '-':
+		return nil, respError(line[1:])
+	case ':':
+		return strconv.ParseInt(string(line[1:]), 10, 64)
+	case '$':
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		out := make([]any, 0, n)
+		for i := 0; i < n; i++ {
+			v, err := readReply(r)
+			if err != nil && !errors.Is(err, errNil) {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("resp: unexpected reply %q", line)
+}
+
+func readLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("resp: malformed line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
@@ -6,76 +6,228 @@ import (
 )
 
 type KV interface {
+	// Get returns fresh values only.
 	Get(key string) (any, bool)
+	// GetStale also returns values past their TTL but still inside the
+	// stale window, reporting them with stale set.
+	GetStale(key string) (v any, stale bool, ok bool)
 	Set(key string, v any)
+	// SetWithTTL overrides the freshness TTL of one entry; ttl <= 0 uses the
+	// cache default. The stale window is unchanged.
+	SetWithTTL(key string, v any, ttl time.Duration)
+}
+
+// Inspector exposes cache internals for metrics and administration.
+type Inspector interface {
+	Stats() Stats
+	// Keys lists the cached entries without their values.
+	Keys() []EntryInfo
+	Entry(key string) (EntryInfo, bool)
+	Delete(key string) bool
+	Purge()
+}
+
+// InspectableKV is a KV that can be inspected and persisted.
+type InspectableKV interface {
+	KV
+	Inspector
+	Snapshotter
+}
+
+// Stats are cumulative counters since the cache was created, plus its
+// current size.
+type Stats struct {
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	Sets        uint64 `json:"sets"`
+	Evictions   uint64 `json:"evictions"`
+	Expirations uint64 `json:"expirations"`
+	Entries     int    `json:"entries"`
+}
+
+// EntryInfo describes one cached entry. ExpiresAt and StaleUntil are zero
+// for entries that never expire.
+type EntryInfo struct {
+	Key        string    `json:"key"`
+	ExpiresAt  time.Time `json:"expiresAt,omitempty"`
+	StaleUntil time.Time `json:"staleUntil,omitempty"`
+	TTL        float64   `json:"ttlSeconds"` // remaining freshness, negative when stale
+	Value      any       `json:"value,omitempty"`
 }
 
 type item struct {
-	v   any
-	exp int64
+	v     any
+	exp   int64 // fresh until
+	stale int64 // servable as stale until
 }
 
 type store struct {
-	mu  sync.RWMutex
-	m   map[string]item
-	ttl time.Duration
-	max int
+	mu       sync.Mutex
+	m        map[string]*entry
+	policy   evictionPolicy
+	ttl      time.Duration
+	staleTTL time.Duration
+	max      int
+	stats    Stats
 }
 
 type Config struct {
 	TTL           int
 	SweepInterval int
 	MaxEntries    int
+	// StaleTTL keeps entries for this many seconds after TTL so they can be
+	// served stale while they are refreshed or while the upstream fails.
+	StaleTTL int
+	// Policy picks the entry evicted when MaxEntries is reached (default LRU).
+	Policy Policy
 }
 
-func NewTTLCache(cfg Config) KV {
-	s := &store{m: make(map[string]item), ttl: time.Duration(cfg.TTL) * time.Second, max: cfg.MaxEntries}
-	go func(interval time.Duration) {
-		tick := time.NewTicker(interval)
-		defer tick.Stop()
-		for range tick.C {
-			s.sweep()
-		}
-	}(time.Duration(cfg.SweepInterval) * time.Second)
+func NewTTLCache(cfg Config) InspectableKV {
+	s := &store{
+		m:        make(map[string]*entry),
+		policy:   newPolicy(cfg.Policy),
+		ttl:      time.Duration(cfg.TTL) * time.Second,
+		staleTTL: time.Duration(cfg.StaleTTL) * time.Second,
+		max:      cfg.MaxEntries,
+	}
+	if cfg.SweepInterval > 0 {
+		go func(interval time.Duration) {
+			tick := time.NewTicker(interval)
+			defer tick.Stop()
+			for range tick.C {
+				s.sweep()
+			}
+		}(time.Duration(cfg.SweepInterval) * time.Second)
+	}
 	return s
 }
 
 func (s *store) Get(key string) (any, bool) {
 	now := time.Now().Unix()
-	s.mu.RLock()
-	it, ok := s.m[key]
-	s.mu.RUnlock()
-	if !ok || (it.exp > 0 && it.exp < now) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.m[key]
+	if !ok || (e.it.exp > 0 && e.it.exp < now) {
+		s.stats.Misses++
 		return nil, false
 	}
-	return it.v, true
+	s.stats.Hits++
+	s.policy.touch(e)
+	return e.it.v, true
 }
 
-func (s *store) Set(key string, v any) {
+func (s *store) GetStale(key string) (any, bool, bool) {
+	now := time.Now().Unix()
 	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.m[key]
+	if !ok || (e.it.stale > 0 && e.it.stale < now) {
+		s.stats.Misses++
+		return nil, false, false
+	}
+	s.stats.Hits++
+	s.policy.touch(e)
+	return e.it.v, e.it.exp > 0 && e.it.exp < now, true
+}
+
+func (s *store) Set(key string, v any) { s.SetWithTTL(key, v, 0) }
+
+func (s *store) SetWithTTL(key string, v any, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = s.ttl
+	}
+	now := time.Now()
+	it := item{v: v, exp: expiry(now, ttl)}
+	if it.exp > 0 {
+		it.stale = expiry(now, ttl+s.staleTTL)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Sets++
+	if e, ok := s.m[key]; ok {
+		e.it = it
+		s.policy.touch(e)
+		return
+	}
 	if s.max > 0 && len(s.m) >= s.max {
 		s.evictOne()
 	}
-	s.m[key] = item{v: v, exp: expiry(time.Now(), s.ttl)}
-	s.mu.Unlock()
+	e := &entry{key: key, it: it}
+	s.m[key] = e
+	s.policy.add(e)
 }
 
 func (s *store) sweep() {
 	now := time.Now().Unix()
 	s.mu.Lock()
-	for k, it := range s.m {
-		if it.exp > 0 && it.exp < now {
+	for k, e := range s.m {
+		if e.it.stale > 0 && e.it.stale < now {
+			s.policy.remove(e)
 			delete(s.m, k)
+			s.stats.Expirations++
 		}
 	}
 	s.mu.Unlock()
 }
 
 func (s *store) evictOne() {
-	for k := range s.m {
-		delete(s.m, k)
-		return
+	if e := s.policy.victim(); e != nil {
+		s.policy.remove(e)
+		delete(s.m, e.key)
+		s.stats.Evictions++
+	}
+}
+
+func (s *store) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.stats
+	st.Entries = len(s.m)
+	return st
+}
+
+func (s *store) Keys() []EntryInfo {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]EntryInfo, 0, len(s.m))
+	for _, e := range s.m {
+		out = append(out, e.info(now))
+	}
+	return out
+}
+
+func (s *store) Entry(key string) (EntryInfo, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.m[key]
+	if !ok {
+		return EntryInfo{}, false
+	}
+	info := e.info(time.Now())
+	info.Value = e.it.v
+	return info, true
+}
+
+func (s *store) Delete(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.m[key]
+	if ok {
+		s.policy.remove(e)
+		delete(s.m, key)
+	}
+	return ok
+}
+
+func (s *store) Purge() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.m {
+		s.policy.remove(e)
 	}
+	clear(s.m)
 }
 
 func expiry(t time.Time, ttl time.Duration) int64 {
@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func newTestCache(p Policy, max int) KV {
+	return NewTTLCache(Config{TTL: 60, MaxEntries: max, Policy: p})
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newTestCache(PolicyLRU, 2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("b should have been evicted")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("%s should still be cached", k)
+		}
+	}
+}
+
+func TestLFU_EvictsLeastFrequentlyUsed(t *testing.T) {
+	c := newTestCache(PolicyLFU, 2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	for i := 0; i < 3; i++ {
+		c.Get("a")
+	}
+	c.Get("b")
+	c.Set("c", 3) // evicts b (2 uses) over a (4 uses)
+	c.Get("c")
+	c.Set("d", 4) // c and d tie with a fresh entry; c is older in its bucket
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("b should have been evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("a should still be cached")
+	}
+	if _, ok := c.Get("c"); ok {
+		t.Fatal("c should have been evicted")
+	}
+}
+
+func TestSet_ExistingKeyDoesNotEvict(t *testing.T) {
+	for _, p := range []Policy{PolicyLRU, PolicyLFU} {
+		c := newTestCache(p, 2)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Set("a", 3)
+		if v, ok := c.Get("a"); !ok || v.(int) != 3 {
+			t.Fatalf("%s: want a=3, got %v %v", p, v, ok)
+		}
+		if _, ok := c.Get("b"); !ok {
+			t.Fatalf("%s: b should still be cached", p)
+		}
+	}
+}
+
+// zipfKeys returns n keys drawn from a Zipf distribution over keySpace keys,
+// a rough model of a few hot locations and a long tail.
+func zipfKeys(n, keySpace int, seed int64) []string {
+	r := rand.New(rand.NewSource(seed))
+	z := rand.NewZipf(r, 1.1, 1, uint64(keySpace-1))
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("lat=%d", z.Uint64())
+	}
+	return keys
+}
+
+func hitRatio(c KV, keys []string) float64 {
+	hits := 0
+	for _, k := range keys {
+		if _, ok := c.Get(k); ok {
+			hits++
+			continue
+		}
+		c.Set(k, struct{}{})
+	}
+	return float64(hits) / float64(len(keys))
+}
+
+func TestHitRatio_SkewedKeys(t *testing.T) {
+	keys := zipfKeys(200000, 10000, 1)
+	ratios := map[Policy]float64{}
+	for _, p := range []Policy{PolicyLRU, PolicyLFU} {
+		ratios[p] = hitRatio(newTestCache(p, 500), keys)
+		t.Logf("%s hit ratio: %.3f", p, ratios[p])
+		if ratios[p] < 0.65 {
+			t.Errorf("%s: hit ratio %.3f below 0.65", p, ratios[p])
+		}
+	}
+	// with a stable popularity distribution frequency beats recency
+	if ratios[PolicyLFU] < ratios[PolicyLRU] {
+		t.Errorf("lfu %.3f should not be worse than lru %.3f", ratios[PolicyLFU], ratios[PolicyLRU])
+	}
+}
+
+func benchmarkPolicy(b *testing.B, p Policy) {
+	keys := zipfKeys(1<<16, 10000, 2)
+	c := newTestCache(p, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := keys[i&(len(keys)-1)]
+		if _, ok := c.Get(k); !ok {
+			c.Set(k, i)
+		}
+	}
+}
+
+func BenchmarkLRU_Zipf(b *testing.B) { benchmarkPolicy(b, PolicyLRU) }
+func BenchmarkLFU_Zipf(b *testing.B) { benchmarkPolicy(b, PolicyLFU) }
+
+func BenchmarkLRU_ZipfParallel(b *testing.B) {
+	keys := zipfKeys(1<<16, 10000, 3)
+	c := newTestCache(PolicyLRU, 1000)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := keys[i&(len(keys)-1)]
+			if _, ok := c.Get(k); !ok {
+				c.Set(k, i)
+			}
+			i++
+		}
+	})
+}
+
+func TestStatsAndInspection(t *testing.T) {
+	c := NewTTLCache(Config{TTL: 60, MaxEntries: 2})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Get("missing")
+	c.Set("c", 3) // evicts b
+
+	st := c.Stats()
+	want := Stats{Hits: 1, Misses: 1, Sets: 3, Evictions: 1, Entries: 2}
+	if st != want {
+		t.Fatalf("want %+v, got %+v", want, st)
+	}
+
+	info, ok := c.Entry("a")
+	if !ok || info.Value.(int) != 1 || info.TTL <= 0 || info.TTL > 60 {
+		t.Fatalf("unexpected entry %+v", info)
+	}
+	if keys := c.Keys(); len(keys) != 2 || keys[0].Value != nil {
+		t.Fatalf("unexpected keys %+v", keys)
+	}
+
+	if !c.Delete("a") || c.Delete("a") {
+		t.Fatal("delete should report whether the key existed")
+	}
+	c.Purge()
+	if st := c.Stats(); st.Entries != 0 {
+		t.Fatalf("want empty cache after purge, got %d entries", st.Entries)
+	}
+	c.Set("d", 4)
+	if _, ok := c.Get("d"); !ok {
+		t.Fatal("cache should keep working after purge")
+	}
+}
@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Snapshot file layout (big endian):
+//
+//	magic   [8]byte "GWCACHE\x00"
+//	version uint16
+//	length  uint64  payload length
+//	payload []byte  JSON array of snapshotRecord
+//	crc     uint32  CRC-32 (IEEE) of payload
+//
+// Files with another version are ignored so a rollback or upgrade never
+// restores entries it cannot interpret.
+const snapshotVersion uint16 = 1
+
+var snapshotMagic = [8]byte{'G', 'W', 'C', 'A', 'C', 'H', 'E', 0}
+
+var (
+	ErrSnapshotVersion = errors.New("cache snapshot: unsupported version")
+	ErrSnapshotCorrupt = errors.New("cache snapshot: corrupt or truncated")
+)
+
+// maxSnapshotPayload guards against allocating absurd sizes from a corrupt
+// length field.
+const maxSnapshotPayload = 1 << 30
+
+// Snapshotter is implemented by caches that can be persisted.
+type Snapshotter interface {
+	// WriteSnapshot writes the unexpired entries whose values codec can
+	// encode.
+	WriteSnapshot(w io.Writer, codec Codec) error
+	// ReadSnapshot restores the entries of a snapshot that have not expired
+	// and reports how many were restored. Nothing is restored on error.
+	ReadSnapshot(r io.Reader, codec Codec) (int, error)
+}
+
+type snapshotRecord struct {
+	Key   string          `json:"k"`
+	Exp   int64           `json:"e"`
+	Stale int64           `json:"s"`
+	Value json.RawMessage `json:"v"`
+}
+
+func (s *store) WriteSnapshot(w io.Writer, codec Codec) error {
+	now := time.Now().Unix()
+	s.mu.Lock()
+	entries := make([]entry, 0, len(s.m))
+	for _, e := range s.m {
+		if e.it.stale > 0 && e.it.stale < now {
+			continue
+		}
+		entries = append(entries, entry{key: e.key, it: e.it})
+	}
+	s.mu.Unlock()
+
+	records := make([]snapshotRecord, 0, len(entries))
+	for _, e := range entries {
+		raw, err := codec.Marshal(e.it.v)
+		if err != nil {
+			continue // unregistered types are not persisted
+		}
+		records = append(records, snapshotRecord{Key: e.key, Exp: e.it.exp, Stale: e.it.stale, Value: raw})
+	}
+	payload, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.Write(snapshotMagic[:])
+	binary.Write(bw, binary.BigEndian, snapshotVersion)
+	binary.Write(bw, binary.BigEndian, uint64(len(payload)))
+	bw.Write(payload)
+	binary.Write(bw, binary.BigEndian, crc32.ChecksumIEEE(payload))
+	return bw.Flush()
+}
+
+func (s *store) ReadSnapshot(r io.Reader, codec Codec) (int, error) {
+	var magic [8]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil || magic != snapshotMagic {
+		return 0, ErrSnapshotCorrupt
+	}
+	var version uint16
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return 0, ErrSnapshotCorrupt
+	}
+	if version != snapshotVersion {
+		return 0, fmt.Errorf("%w: %d", ErrSnapshotVersion, version)
+	}
+	var n uint64
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil || n > maxSnapshotPayload {
+		return 0, ErrSnapshotCorrupt
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, ErrSnapshotCorrupt
+	}
+	var sum uint32
+	if err := binary.Read(r, binary.BigEndian, &sum); err != nil || sum != crc32.ChecksumIEEE(payload) {
+		return 0, ErrSnapshotCorrupt
+	}
+
+	var records []snapshotRecord
+	if err := json.Unmarshal(payload, &records); err != nil {
+		return 0, ErrSnapshotCorrupt
+	}
+
+	now := time.Now().Unix()
+	items := make(map[string]item, len(records))
+	for _, rec := range records {
+		if rec.Stale > 0 && rec.Stale < now {
+			continue
+		}
+		v, err := codec.Unmarshal(rec.Value)
+		if err != nil {
+			continue // type no longer registered
+		}
+		items[rec.Key] = item{v: v, exp: rec.Exp, stale: rec.Stale}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, it := range items {
+		if e, ok := s.m[k]; ok {
+			e.it = it
+			continue
+		}
+		if s.max > 0 && len(s.m) >= s.max {
+			s.evictOne()
+		}
+		e := &entry{key: k, it: it}
+		s.m[k] = e
+		s.policy.add(e)
+	}
+	return len(items), nil
+}
+
+// SaveSnapshot writes a snapshot of c to path atomically.
+func SaveSnapshot(path string, c Snapshotter, codec Codec) error {
+	var buf bytes.Buffer
+	if err := c.WriteSnapshot(&buf, codec); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadSnapshot restores c from path. A missing file restores nothing and is
+// not an error.
+func LoadSnapshot(path string, c Snapshotter, codec Codec) (int, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return c.ReadSnapshot(bufio.NewReader(f), codec)
+}
+
+// RunSnapshots saves c to path every interval until ctx is done. A
+// non-positive interval disables periodic saves and returns at once.
+func RunSnapshots(ctx context.Context, path string, interval time.Duration, c Snapshotter, codec Codec) {
+	if interval <= 0 {
+		return
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			if err := SaveSnapshot(path, c, codec); err != nil {
+				log.Printf("cache: snapshot %s: %v", path, err)
+			}
+		}
+	}
+}
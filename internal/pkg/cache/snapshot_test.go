@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func snapshotOf(t *testing.T, c InspectableKV) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := c.WriteSnapshot(&buf, newTestCodec()); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSnapshot_RoundTripDropsExpired(t *testing.T) {
+	src := NewTTLCache(Config{TTL: 60, StaleTTL: 60, MaxEntries: 10})
+	src.Set("a", forecast{Short: "Sunny", TempF: 80})
+	src.Set("b", "unregistered types are skipped")
+	src.SetWithTTL("c", forecast{Short: "Rain"}, time.Hour)
+	// d is past its stale window and must not be restored
+	src.(*store).m["d"] = &entry{key: "d", it: item{v: forecast{}, exp: 1, stale: 2}}
+	src.(*store).policy.add(src.(*store).m["d"])
+
+	path := filepath.Join(t.TempDir(), "forecast.snap")
+	if err := SaveSnapshot(path, src, newTestCodec()); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+	n, err := LoadSnapshot(path, dst, newTestCodec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("want 2 restored entries, got %d", n)
+	}
+	if v, ok := dst.Get("a"); !ok || v.(forecast).TempF != 80 {
+		t.Fatalf("unexpected a: %v %v", v, ok)
+	}
+	info, _ := dst.Entry("c")
+	if info.TTL < 3500 {
+		t.Fatalf("restored entries keep their expiry, got %.0fs", info.TTL)
+	}
+	for _, k := range []string{"b", "d"} {
+		if _, ok := dst.Entry(k); ok {
+			t.Fatalf("%s should not be restored", k)
+		}
+	}
+}
+
+func TestSnapshot_RejectsDamagedFiles(t *testing.T) {
+	src := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+	src.Set("a", forecast{Short: "Sunny"})
+	good := snapshotOf(t, src)
+
+	flipped := bytes.Clone(good)
+	flipped[len(flipped)/2] ^= 0xff
+
+	otherVersion := bytes.Clone(good)
+	binary.BigEndian.PutUint16(otherVersion[8:], snapshotVersion+1)
+
+	hugeLength := bytes.Clone(good)
+	binary.BigEndian.PutUint64(hugeLength[10:], 1<<62)
+
+	cases := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, ErrSnapshotCorrupt},
+		{"not a snapshot", []byte("hello world, definitely not a snapshot"), ErrSnapshotCorrupt},
+		{"truncated header", good[:9], ErrSnapshotCorrupt},
+		{"truncated payload", good[:len(good)-10], ErrSnapshotCorrupt},
+		{"missing checksum", good[:len(good)-4], ErrSnapshotCorrupt},
+		{"flipped byte", flipped, ErrSnapshotCorrupt},
+		{"huge length", hugeLength, ErrSnapshotCorrupt},
+		{"other version", otherVersion, ErrSnapshotVersion},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+			n, err := dst.ReadSnapshot(bytes.NewReader(tc.data), newTestCodec())
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("want %v, got %v", tc.want, err)
+			}
+			if n != 0 || dst.Stats().Entries != 0 {
+				t.Fatal("nothing should be restored from a damaged snapshot")
+			}
+		})
+	}
+}
+
+func TestLoadSnapshot_MissingFile(t *testing.T) {
+	dst := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+	n, err := LoadSnapshot(filepath.Join(t.TempDir(), "none.snap"), dst, newTestCodec())
+	if err != nil || n != 0 {
+		t.Fatalf("want 0, nil; got %d, %v", n, err)
+	}
+}
+
+func TestSaveSnapshot_LeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	src := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+	src.Set("a", forecast{Short: "Sunny"})
+	for i := 0; i < 2; i++ {
+		if err := SaveSnapshot(filepath.Join(dir, "forecast.snap"), src, newTestCodec()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 {
+		t.Fatalf("want only the snapshot file, got %d files", len(files))
+	}
+}
+
+func TestRunSnapshots_NonPositiveIntervalSavesNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "forecast.snap")
+	c := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+	done := make(chan struct{})
+	go func() {
+		RunSnapshots(context.Background(), path, 0, c, newTestCodec())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("want RunSnapshots to return for a zero interval")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("want no snapshot written, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Loader produces the value for a missing key. A positive TTL overrides the
+// cache's default for that entry.
+type Loader[V any] func(ctx context.Context) (V, time.Duration, error)
+
+// Typed is a type-safe view over a KV. Several typed caches may share one KV
+// as long as their prefixes differ. Values of another type found under a key
+// are treated as misses. A nil KV caches nothing but still coalesces loads.
+type Typed[V any] struct {
+	kv          KV
+	prefix      string
+	group       singleflight.Group
+	onCoalesced func()
+}
+
+type TypedOption func(*typedOptions)
+
+type typedOptions struct{ onCoalesced func() }
+
+// WithCoalescedHook calls fn whenever a load joins one already in flight.
+func WithCoalescedHook(fn func()) TypedOption {
+	return func(o *typedOptions) { o.onCoalesced = fn }
+}
+
+func NewTyped[V any](kv KV, prefix string, opts ...TypedOption) *Typed[V] {
+	var o typedOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Typed[V]{kv: kv, prefix: prefix, onCoalesced: o.onCoalesced}
+}
+
+func (c *Typed[V]) Get(key string) (V, bool) {
+	var zero V
+	if c.kv == nil {
+		return zero, false
+	}
+	v, ok := c.kv.Get(c.prefix + key)
+	if !ok {
+		return zero, false
+	}
+	tv, ok := v.(V)
+	return tv, ok
+}
+
+// GetStale returns fresh or stale values, see KV.GetStale.
+func (c *Typed[V]) GetStale(key string) (V, bool, bool) {
+	var zero V
+	if c.kv == nil {
+		return zero, false, false
+	}
+	v, stale, ok := c.kv.GetStale(c.prefix + key)
+	if !ok {
+		return zero, false, false
+	}
+	tv, ok := v.(V)
+	return tv, stale && ok, ok
+}
+
+func (c *Typed[V]) Set(key string, v V) { c.SetWithTTL(key, v, 0) }
+
+// SetWithTTL stores v for ttl; a ttl <= 0 uses the cache default.
+func (c *Typed[V]) SetWithTTL(key string, v V, ttl time.Duration) {
+	if c.kv != nil {
+		c.kv.SetWithTTL(c.prefix+key, v, ttl)
+	}
+}
+
+// GetOrLoad returns the cached value for key or loads and stores it. Only one
+// load per key runs at a time; concurrent callers wait for it. The load is
+// detached from ctx because callers share it, but each caller stops waiting
+// when its own ctx is done.
+func (c *Typed[V]) GetOrLoad(ctx context.Context, key string, load Loader[V]) (V, error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+	return c.Refresh(ctx, key, load)
+}
+
+// Refresh loads and stores the value for key even if it is cached, joining a
+// load already in flight.
+func (c *Typed[V]) Refresh(ctx context.Context, key string, load Loader[V]) (V, error) {
+	leader := false
+	ch := c.group.DoChan(key, func() (any, error) {
+		leader = true
+		v, ttl, err := load(context.WithoutCancel(ctx))
+		if err != nil {
+			return v, err
+		}
+		c.SetWithTTL(key, v, ttl)
+		return v, nil
+	})
+
+	var zero V
+	select {
+	case res := <-ch:
+		if !leader && c.onCoalesced != nil {
+			c.onCoalesced()
+		}
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(V), nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTyped_ForeignValueIsAMiss(t *testing.T) {
+	kv := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+	kv.Set("n:a", "not an int")
+	c := NewTyped[int](kv, "n:")
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("value of another type must not be returned")
+	}
+	got, err := c.GetOrLoad(context.Background(), "a", func(context.Context) (int, time.Duration, error) { return 7, 0, nil })
+	if err != nil || got != 7 {
+		t.Fatalf("want 7, got %d %v", got, err)
+	}
+	if v, ok := kv.Get("n:a"); !ok || v.(int) != 7 {
+		t.Fatal("loaded value should replace the foreign one")
+	}
+}
+
+func TestTyped_PerEntryTTL(t *testing.T) {
+	kv := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+	c := NewTyped[int](kv, "")
+	_, err := c.GetOrLoad(context.Background(), "a", func(context.Context) (int, time.Duration, error) { return 1, 10 * time.Minute, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, _ := kv.Entry("a")
+	if info.TTL < 590 {
+		t.Fatalf("want the loader TTL to override the default, got %.0fs", info.TTL)
+	}
+}
+
+func TestTyped_CallerCancellationDoesNotAbortLoad(t *testing.T) {
+	kv := NewTTLCache(Config{TTL: 60, MaxEntries: 10})
+	coalesced := 0
+	c := NewTyped[int](kv, "", WithCoalescedHook(func() { coalesced++ }))
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	load := func(ctx context.Context) (int, time.Duration, error) {
+		defer close(done)
+		<-release
+		return 42, 0, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetOrLoad(ctx, "a", load); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+
+	close(release)
+	<-done
+	deadline := time.Now().Add(time.Second)
+	for {
+		if v, ok := c.Get("a"); ok {
+			if v != 42 {
+				t.Fatalf("want 42, got %d", v)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("detached load should still populate the cache")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTyped_NilKVStillLoads(t *testing.T) {
+	c := NewTyped[string](nil, "")
+	calls := 0
+	for i := 0; i < 2; i++ {
+		v, err := c.GetOrLoad(context.Background(), "a", func(context.Context) (string, time.Duration, error) {
+			calls++
+			return "x", 0, nil
+		})
+		if err != nil || v != "x" {
+			t.Fatalf("want x, got %q %v", v, err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("nil KV caches nothing, want 2 loads, got %d", calls)
+	}
+}
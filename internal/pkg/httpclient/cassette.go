@@ -0,0 +1,151 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"unicode/utf8"
+)
+
+// CassetteMode selects whether traffic is recorded to or replayed from a
+// cassette directory.
+type CassetteMode string
+
+const (
+	// Record sends requests upstream and saves every request/response pair.
+	// Conditional request headers are dropped so that a recording always
+	// holds the full response rather than a 304 that could not be replayed.
+	Record CassetteMode = "record"
+	// Replay serves saved responses and never touches the network.
+	Replay CassetteMode = "replay"
+)
+
+// ParseCassetteMode accepts "", "off", "record" and "replay"; "" and "off"
+// return an empty mode.
+func ParseCassetteMode(s string) (CassetteMode, error) {
+	switch m := CassetteMode(s); m {
+	case "", "off":
+		return "", nil
+	case Record, Replay:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown cassette mode %q", s)
+}
+
+// interaction is the file format of one recorded request/response pair.
+type interaction struct {
+	Request struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"headers"`
+	} `json:"request"`
+	Response struct {
+		Status int         `json:"status"`
+		Header http.Header `json:"headers"`
+		Body   string      `json:"body,omitempty"`
+		// BodyBase64 holds bodies that are not valid UTF-8.
+		BodyBase64 string `json:"body_base64,omitempty"`
+	} `json:"response"`
+}
+
+type cassette struct {
+	base http.RoundTripper
+	mode CassetteMode
+	dir  string
+}
+
+func (c cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := filepath.Join(c.dir, cassetteName(req))
+	if c.mode == Replay {
+		return c.replay(req, path)
+	}
+
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		req = req.Clone(req.Context())
+		req.Header.Del("If-None-Match")
+		req.Header.Del("If-Modified-Since")
+	}
+	resp, err := c.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var it interaction
+	it.Request.Method = req.Method
+	it.Request.URL = req.URL.String()
+	it.Request.Header = req.Header
+	it.Response.Status = resp.StatusCode
+	it.Response.Header = resp.Header
+	if utf8.Valid(body) {
+		it.Response.Body = string(body)
+	} else {
+		it.Response.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+	b, err := json.MarshalIndent(it, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("record %s: %w", req.URL, err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return nil, fmt.Errorf("record %s: %w", req.URL, err)
+	}
+	return resp, nil
+}
+
+func (c cassette) replay(req *http.Request, path string) (*http.Response, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("replay %s %s: %w", req.Method, req.URL, err)
+	}
+	var it interaction
+	if err := json.Unmarshal(b, &it); err != nil {
+		return nil, fmt.Errorf("replay %s: %w", path, err)
+	}
+	body := []byte(it.Response.Body)
+	if it.Response.BodyBase64 != "" {
+		if body, err = base64.StdEncoding.DecodeString(it.Response.BodyBase64); err != nil {
+			return nil, fmt.Errorf("replay %s: %w", path, err)
+		}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", it.Response.Status, http.StatusText(it.Response.Status)),
+		StatusCode:    it.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        it.Response.Header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9.,_-]+`)
+
+// cassetteName maps a request to a stable file name: a readable prefix from
+// the method and path plus a hash of the full URL, so that repeated requests
+// replay the same recording.
+func cassetteName(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String()))
+	prefix := unsafeChars.ReplaceAllString(req.Method+"_"+req.URL.Path, "_")
+	if len(prefix) > 80 {
+		prefix = prefix[:80]
+	}
+	return prefix + "_" + hex.EncodeToString(sum[:6]) + ".json"
+}
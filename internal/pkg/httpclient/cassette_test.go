@@ -0,0 +1,64 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestCassette_RecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/ld+json")
+		w.Header().Set("X-Path", r.URL.Path)
+		switch r.URL.Path {
+		case "/binary":
+			_, _ = w.Write([]byte{0xff, 0xfe, 0x00})
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(`{"periods":[]}`))
+		}
+	}))
+	paths := []string{"/points/38.8894,-77.0352", "/binary", "/missing"}
+
+	get := func(c *http.Client, path string) (*http.Response, []byte) {
+		t.Helper()
+		resp, err := c.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return resp, b
+	}
+
+	rec := New("test", WithCassette(Record, dir))
+	want := map[string][]byte{}
+	for _, p := range paths {
+		_, want[p] = get(rec, p)
+	}
+	srv.Close()
+	files, _ := os.ReadDir(dir)
+	if len(files) != len(paths) {
+		t.Fatalf("want %d recordings, got %d", len(paths), len(files))
+	}
+
+	play := New("test", WithCassette(Replay, dir))
+	for i := 0; i < 2; i++ {
+		for _, p := range paths {
+			resp, body := get(play, p)
+			if string(body) != string(want[p]) || resp.Header.Get("X-Path") != p {
+				t.Fatalf("%s: replayed %q, recorded %q", p, body, want[p])
+			}
+			if p == "/missing" && resp.StatusCode != http.StatusNotFound {
+				t.Fatalf("want the recorded 404, got %d", resp.StatusCode)
+			}
+		}
+	}
+	if _, err := play.Get(srv.URL + "/never-recorded"); err == nil {
+		t.Fatal("want an error for a request missing from the cassette")
+	}
+}
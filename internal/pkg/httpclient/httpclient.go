@@ -5,9 +5,30 @@ import (
 	"time"
 )
 
-func New(userAgent string) *http.Client {
-	tr := &http.Transport{Proxy: http.ProxyFromEnvironment, MaxIdleConns: 100, IdleConnTimeout: 90 * time.Second}
-	return &http.Client{Transport: roundTripper{base: tr, ua: userAgent}, Timeout: 12 * time.Second}
+type options struct {
+	cassetteMode CassetteMode
+	cassetteDir  string
+}
+
+type Option func(*options)
+
+// WithCassette records the traffic to dir or replays it from there, depending
+// on mode. An empty mode leaves the client untouched.
+func WithCassette(mode CassetteMode, dir string) Option {
+	return func(o *options) { o.cassetteMode, o.cassetteDir = mode, dir }
+}
+
+func New(userAgent string, opts ...Option) *http.Client {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var base http.RoundTripper = &http.Transport{Proxy: http.ProxyFromEnvironment, MaxIdleConns: 100, IdleConnTimeout: 90 * time.Second}
+	if o.cassetteMode != "" {
+		base = cassette{base: base, mode: o.cassetteMode, dir: o.cassetteDir}
+	}
+	return &http.Client{Transport: roundTripper{base: base, ua: userAgent}, Timeout: 12 * time.Second}
 }
 
 type roundTripper struct {
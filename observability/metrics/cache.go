@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rcglezreyes/go_weather/internal/pkg/cache"
+)
+
+var (
+	cacheHitsDesc        = cacheDesc("hits_total", "Cache lookups that found a value")
+	cacheMissesDesc      = cacheDesc("misses_total", "Cache lookups that found nothing")
+	cacheSetsDesc        = cacheDesc("sets_total", "Values written to the cache")
+	cacheEvictionsDesc   = cacheDesc("evictions_total", "Entries evicted to honor the size limit")
+	cacheExpirationsDesc = cacheDesc("expirations_total", "Entries removed after expiring")
+	cacheEntriesDesc     = prometheus.NewDesc("go_weather_cache_entries", "Entries currently cached", []string{"cache"}, nil)
+
+	caches = &cacheCollector{m: map[string]cache.Inspector{}}
+)
+
+func cacheDesc(name, help string) *prometheus.Desc {
+	return prometheus.NewDesc("go_weather_cache_"+name, help, []string{"cache"}, nil)
+}
+
+// RegisterCache exports the statistics of c labeled with cache=name.
+func RegisterCache(name string, c cache.Inspector) {
+	caches.mu.Lock()
+	caches.m[name] = c
+	caches.mu.Unlock()
+}
+
+// cacheCollector reads cache stats at scrape time.
+type cacheCollector struct {
+	mu sync.RWMutex
+	m  map[string]cache.Inspector
+}
+
+func (cc *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheHitsDesc
+	ch <- cacheMissesDesc
+	ch <- cacheSetsDesc
+	ch <- cacheEvictionsDesc
+	ch <- cacheExpirationsDesc
+	ch <- cacheEntriesDesc
+}
+
+func (cc *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	for name, c := range cc.m {
+		st := c.Stats()
+		ch <- prometheus.MustNewConstMetric(cacheHitsDesc, prometheus.CounterValue, float64(st.Hits), name)
+		ch <- prometheus.MustNewConstMetric(cacheMissesDesc, prometheus.CounterValue, float64(st.Misses), name)
+		ch <- prometheus.MustNewConstMetric(cacheSetsDesc, prometheus.CounterValue, float64(st.Sets), name)
+		ch <- prometheus.MustNewConstMetric(cacheEvictionsDesc, prometheus.CounterValue, float64(st.Evictions), name)
+		ch <- prometheus.MustNewConstMetric(cacheExpirationsDesc, prometheus.CounterValue, float64(st.Expirations), name)
+		ch <- prometheus.MustNewConstMetric(cacheEntriesDesc, prometheus.GaugeValue, float64(st.Entries), name)
+	}
+}
@@ -17,6 +17,30 @@ var (
 		Namespace: "go_weather", Subsystem: "nws", Name: "request_duration_seconds", Help: "Duration of requests to client NWS",
 		Buckets: prometheus.DefBuckets,
 	})
+	NWSRetriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go_weather", Subsystem: "nws", Name: "retries_total", Help: "Requests to client NWS retried, by the status code or error that caused the retry",
+	}, []string{"reason"})
+	NWSConditionalRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go_weather", Subsystem: "nws", Name: "conditional_requests_total", Help: "Conditional forecast requests to client NWS by result (not_modified for 304, modified for 200)",
+	}, []string{"result"})
+	NWSBreakerState = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "go_weather", Subsystem: "nws", Name: "breaker_state", Help: "State of the NWS circuit breaker (0 closed, 1 open, 2 half-open)",
+	})
+	CoalescedRequestsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "go_weather", Subsystem: "cache", Name: "coalesced_requests_total", Help: "Cache misses served by an upstream fetch already in flight for the same key",
+	})
+	StaleServedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "go_weather", Subsystem: "cache", Name: "stale_served_total", Help: "Forecasts served from cache past their TTL",
+	})
+	WarmerLocations = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "go_weather", Subsystem: "warmer", Name: "locations", Help: "Locations kept warm in the forecast cache",
+	})
+	WarmerWarmed = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "go_weather", Subsystem: "warmer", Name: "warmed_locations", Help: "Locations whose last refresh succeeded",
+	})
+	WarmerRefreshesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go_weather", Subsystem: "warmer", Name: "refreshes_total", Help: "Warmer refreshes by result (ok, error)",
+	}, []string{"result"})
 )
 
 func register(c prometheus.Collector) {
@@ -34,5 +58,14 @@ func Init() {
 		register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 		register(NWSRequestsTotal)
 		register(NWSRequestDuration)
+		register(NWSRetriesTotal)
+		register(NWSBreakerState)
+		register(NWSConditionalRequestsTotal)
+		register(CoalescedRequestsTotal)
+		register(StaleServedTotal)
+		register(WarmerLocations)
+		register(WarmerWarmed)
+		register(WarmerRefreshesTotal)
+		register(caches)
 	})
 }